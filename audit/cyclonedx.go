@@ -0,0 +1,94 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import (
+	"bytes"
+	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+// CycloneDxFormatter renders audit results as a CycloneDX BOM, enriched with
+// a VEX vulnerabilities section, in either JSON or XML
+type CycloneDxFormatter struct {
+	Format cdx.BOMFileFormat
+}
+
+// Format implements logrus.Formatter
+func (f *CycloneDxFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	coordinates, _ := entry.Data["coordinates"].([]types.Coordinate)
+
+	bom := cdx.NewBOM()
+	components := make([]cdx.Component, 0, len(coordinates))
+	vulnerabilities := make([]cdx.Vulnerability, 0)
+
+	for _, coordinate := range coordinates {
+		components = append(components, cdx.Component{
+			Type:       cdx.ComponentTypeLibrary,
+			PackageURL: coordinate.Coordinates,
+			BOMRef:     coordinate.Coordinates,
+		})
+
+		for _, v := range coordinate.Vulnerabilities {
+			state := cdx.IAStateExploitable
+			if v.Excluded {
+				state = cdx.IAStateResolved
+			}
+
+			score := v.CvssScore
+			ratings := &[]cdx.VulnerabilityRating{
+				{
+					Score:  &score,
+					Vector: v.CvssVector,
+					Method: cdx.ScoringMethodCVSSv3,
+				},
+			}
+
+			advisories := &[]cdx.Advisory{}
+			if v.Reference != "" {
+				*advisories = append(*advisories, cdx.Advisory{URL: v.Reference})
+			}
+
+			vulnerabilities = append(vulnerabilities, cdx.Vulnerability{
+				ID:          ruleIDFor(v),
+				Description: v.Description,
+				Ratings:     ratings,
+				Advisories:  advisories,
+				Analysis: &cdx.VulnerabilityAnalysis{
+					State: state,
+				},
+				Affects: &[]cdx.Affects{
+					{Ref: coordinate.Coordinates},
+				},
+			})
+		}
+	}
+
+	bom.Components = &components
+	bom.Vulnerabilities = &vulnerabilities
+
+	var buf bytes.Buffer
+	encoder := cdx.NewBOMEncoder(&buf, f.Format)
+	encoder.SetPretty(true)
+	if err := encoder.Encode(bom); err != nil {
+		return nil, fmt.Errorf("encoding CycloneDX BOM: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+func TestCycloneDxFormatterFormat(t *testing.T) {
+	coordinates := []types.Coordinate{
+		{
+			Coordinates: "pkg:golang/example.com/foo@1.0.0",
+			Vulnerabilities: []types.Vulnerability{
+				{ID: "CVE-2023-1111", Title: "bug one", CvssScore: 9.8},
+				{ID: "CVE-2023-2222", Title: "bug two", CvssScore: 4.5},
+			},
+		},
+		{
+			Coordinates: "pkg:golang/example.com/bar@2.0.0",
+			Vulnerabilities: []types.Vulnerability{
+				{ID: "CVE-2023-3333", Title: "bug three", CvssScore: 7.1},
+			},
+		},
+	}
+
+	entry := &logrus.Entry{Data: logrus.Fields{"coordinates": coordinates}}
+
+	f := &CycloneDxFormatter{Format: cdx.BOMFileFormatJSON}
+	out, err := f.Format(entry)
+	if err != nil {
+		t.Fatalf("Format: unexpected error: %v", err)
+	}
+
+	var bom cdx.BOM
+	if err := json.Unmarshal(out, &bom); err != nil {
+		t.Fatalf("Format produced invalid JSON: %v", err)
+	}
+
+	if bom.Components == nil || len(*bom.Components) != 2 {
+		t.Fatalf("expected 2 components, got %v", bom.Components)
+	}
+	if bom.Vulnerabilities == nil || len(*bom.Vulnerabilities) != 3 {
+		t.Fatalf("expected 3 vulnerabilities, got %v", bom.Vulnerabilities)
+	}
+
+	// each vulnerability's score must reflect its own CvssScore, not the
+	// last one processed across the double loop
+	scores := map[string]float64{}
+	for _, v := range *bom.Vulnerabilities {
+		if v.Ratings == nil || len(*v.Ratings) == 0 || (*v.Ratings)[0].Score == nil {
+			t.Fatalf("vulnerability %s missing a rating score", v.ID)
+		}
+		scores[v.ID] = *(*v.Ratings)[0].Score
+	}
+	want := map[string]float64{"CVE-2023-1111": 9.8, "CVE-2023-2222": 4.5, "CVE-2023-3333": 7.1}
+	for id, score := range want {
+		if scores[id] != score {
+			t.Errorf("vulnerability %s: got score %v, want %v", id, scores[id], score)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package audit renders audit results in the formats Nancy supports, and
+// decides the process exit code for a completed scan
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+// JsonFormatter renders a logrus entry holding audit results as JSON
+type JsonFormatter struct {
+	PrettyPrint bool
+}
+
+// Format implements logrus.Formatter
+func (f *JsonFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if f.PrettyPrint {
+		data, err := json.MarshalIndent(entry.Data, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+	data, err := json.Marshal(entry.Data)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+var (
+	vulnerableColor = color.New(color.FgRed, color.Bold)
+	dimColor        = color.New(color.Faint)
+)
+
+// AuditLogTextFormatter renders audit results as human readable text
+type AuditLogTextFormatter struct {
+	Quiet   *bool
+	NoColor *bool
+}
+
+// Format implements logrus.Formatter. It expects the entry.Data produced by
+// audit.LogResults: "coordinates" ([]types.Coordinate), "package_count" and
+// "vulnerable_count" (int).
+func (f *AuditLogTextFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	coordinates, _ := entry.Data["coordinates"].([]types.Coordinate)
+	packageCount, _ := entry.Data["package_count"].(int)
+	vulnerableCount, _ := entry.Data["vulnerable_count"].(int)
+
+	quiet := f.Quiet != nil && *f.Quiet
+	noColor := f.NoColor != nil && *f.NoColor
+
+	var buf bytes.Buffer
+	for _, coordinate := range coordinates {
+		vulnerable := coordinate.IsVulnerableTo()
+		if quiet && !vulnerable {
+			continue
+		}
+
+		fmt.Fprintln(&buf, headerLine(coordinate.Coordinates, vulnerable, noColor))
+		for _, v := range coordinate.Vulnerabilities {
+			if v.Excluded {
+				continue
+			}
+			fmt.Fprintln(&buf, vulnerabilityLine(v, noColor))
+		}
+	}
+
+	fmt.Fprintf(&buf, "\nAudited %d %s, %d %s found\n",
+		packageCount, pluralize(packageCount, "dependency", "dependencies"),
+		vulnerableCount, pluralize(vulnerableCount, "vulnerability", "vulnerabilities"))
+
+	return buf.Bytes(), nil
+}
+
+// headerLine renders a package coordinate, in red when it's actually
+// vulnerable and colorization is enabled
+func headerLine(purl string, vulnerable bool, noColor bool) string {
+	if noColor || !vulnerable {
+		return purl
+	}
+	return vulnerableColor.Sprint(purl)
+}
+
+// vulnerabilityLine renders one vulnerability line, dimmed when it's
+// Informational or Suppressed, since those findings don't affect the exit
+// code and shouldn't compete for the reader's attention with ones that do
+func vulnerabilityLine(v types.Vulnerability, noColor bool) string {
+	line := fmt.Sprintf("  [%s] %s (CVSS %.1f)%s", labelFor(v), v.Title, v.CvssScore, statusSuffix(v))
+	if noColor {
+		return line
+	}
+	if v.Informational || v.Suppressed {
+		return dimColor.Sprint(line)
+	}
+	return vulnerableColor.Sprint(line)
+}
+
+// labelFor prefers the CVE id, falling back to the data source's own id
+func labelFor(v types.Vulnerability) string {
+	if v.Cve != "" {
+		return v.Cve
+	}
+	return v.ID
+}
+
+// statusSuffix annotates a vulnerability line with why it's dimmed/flagged,
+// if it is
+func statusSuffix(v types.Vulnerability) string {
+	switch {
+	case v.Informational:
+		return " - informational, not reachable from main"
+	case v.IgnoreExpired:
+		return " - ignore entry expired, re-surfaced"
+	case v.Suppressed:
+		return " - suppressed by --fail-on/--min-cvss policy"
+	default:
+		return ""
+	}
+}
+
+func pluralize(n int, singular string, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// CsvFormatter renders audit results as CSV rows
+type CsvFormatter struct {
+	Quiet *bool
+}
+
+// Format implements logrus.Formatter. It expects the same entry.Data as
+// AuditLogTextFormatter.
+func (f *CsvFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	coordinates, _ := entry.Data["coordinates"].([]types.Coordinate)
+	quiet := f.Quiet != nil && *f.Quiet
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write([]string{"coordinates", "id", "title", "cvss_score", "status"}); err != nil {
+		return nil, err
+	}
+
+	for _, coordinate := range coordinates {
+		if quiet && !coordinate.IsVulnerableTo() {
+			continue
+		}
+		for _, v := range coordinate.Vulnerabilities {
+			if quiet && (v.Excluded || v.Informational || v.Suppressed) {
+				continue
+			}
+			row := []string{
+				coordinate.Coordinates,
+				labelFor(v),
+				v.Title,
+				strconv.FormatFloat(v.CvssScore, 'f', 1, 64),
+				statusFor(v),
+			}
+			if err := w.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// statusFor is the CSV-friendly equivalent of statusSuffix
+func statusFor(v types.Vulnerability) string {
+	switch {
+	case v.Excluded:
+		return "excluded"
+	case v.Informational:
+		return "informational"
+	case v.IgnoreExpired:
+		return "ignore_expired"
+	case v.Suppressed:
+		return "suppressed"
+	default:
+		return "vulnerable"
+	}
+}
@@ -0,0 +1,125 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+func testEntry(coordinates []types.Coordinate) *logrus.Entry {
+	vulnerableCount := 0
+	for _, c := range coordinates {
+		if c.IsVulnerableTo() {
+			vulnerableCount++
+		}
+	}
+	return &logrus.Entry{Data: logrus.Fields{
+		"package_count":    len(coordinates),
+		"vulnerable_count": vulnerableCount,
+		"coordinates":      coordinates,
+	}}
+}
+
+func TestAuditLogTextFormatterFormat(t *testing.T) {
+	coordinates := []types.Coordinate{
+		{
+			Coordinates: "pkg:golang/example.com/vulnerable@1.0.0",
+			Vulnerabilities: []types.Vulnerability{
+				{ID: "CVE-2023-1234", Title: "bad stuff", CvssScore: 9.8},
+			},
+		},
+		{
+			Coordinates:     "pkg:golang/example.com/clean@1.0.0",
+			Vulnerabilities: nil,
+		},
+	}
+
+	noColor := true
+	f := &AuditLogTextFormatter{NoColor: &noColor}
+	out, err := f.Format(testEntry(coordinates))
+	if err != nil {
+		t.Fatalf("Format: unexpected error: %v", err)
+	}
+
+	text := string(out)
+	if !strings.Contains(text, "pkg:golang/example.com/vulnerable@1.0.0") {
+		t.Errorf("expected vulnerable coordinate in output, got: %s", text)
+	}
+	if !strings.Contains(text, "CVE-2023-1234") {
+		t.Errorf("expected CVE id in output, got: %s", text)
+	}
+	if !strings.Contains(text, "Audited 2") {
+		t.Errorf("expected package count summary in output, got: %s", text)
+	}
+}
+
+func TestAuditLogTextFormatterQuiet(t *testing.T) {
+	coordinates := []types.Coordinate{
+		{Coordinates: "pkg:golang/example.com/clean@1.0.0"},
+		{
+			Coordinates: "pkg:golang/example.com/vulnerable@1.0.0",
+			Vulnerabilities: []types.Vulnerability{
+				{ID: "CVE-2023-1234", Title: "bad stuff", CvssScore: 9.8},
+			},
+		},
+	}
+
+	quiet := true
+	noColor := true
+	f := &AuditLogTextFormatter{Quiet: &quiet, NoColor: &noColor}
+	out, err := f.Format(testEntry(coordinates))
+	if err != nil {
+		t.Fatalf("Format: unexpected error: %v", err)
+	}
+
+	text := string(out)
+	if strings.Contains(text, "clean") {
+		t.Errorf("quiet output should not mention clean packages, got: %s", text)
+	}
+	if !strings.Contains(text, "vulnerable@1.0.0") {
+		t.Errorf("quiet output should still mention vulnerable packages, got: %s", text)
+	}
+}
+
+func TestCsvFormatterFormat(t *testing.T) {
+	coordinates := []types.Coordinate{
+		{
+			Coordinates: "pkg:golang/example.com/vulnerable@1.0.0",
+			Vulnerabilities: []types.Vulnerability{
+				{ID: "CVE-2023-1234", Title: "bad stuff", CvssScore: 9.8},
+			},
+		},
+	}
+
+	f := &CsvFormatter{}
+	out, err := f.Format(testEntry(coordinates))
+	if err != nil {
+		t.Fatalf("Format: unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header row and one data row, got %d lines: %v", len(lines), lines)
+	}
+	if !strings.Contains(lines[1], "CVE-2023-1234") || !strings.Contains(lines[1], "vulnerable") {
+		t.Errorf("unexpected CSV row: %s", lines[1])
+	}
+}
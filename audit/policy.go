@@ -0,0 +1,69 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import "github.com/sonatype-nexus-community/nancy/types"
+
+// severityRank orders the --fail-on severity names from least to most severe
+var severityRank = map[string]int{
+	"none":     0,
+	"low":      1,
+	"medium":   2,
+	"high":     3,
+	"critical": 4,
+}
+
+// SeverityFor buckets a CVSS score into the same none/low/medium/high/critical
+// scale used by --fail-on, following the NVD's CVSS v3 qualitative ratings
+func SeverityFor(cvssScore float64) string {
+	switch {
+	case cvssScore >= 9.0:
+		return "critical"
+	case cvssScore >= 7.0:
+		return "high"
+	case cvssScore >= 4.0:
+		return "medium"
+	case cvssScore > 0:
+		return "low"
+	default:
+		return "none"
+	}
+}
+
+// Policy is the set of thresholds --fail-on, --min-cvss and
+// --fail-on-unfixed translate into; a vulnerability that doesn't meet it is
+// still reported, but marked Suppressed instead of counting towards the
+// process exit code
+type Policy struct {
+	FailOnSeverity string
+	MinCvss        float64
+	FailOnUnfixed  bool
+}
+
+// Meets reports whether v is severe enough to fail the build under p
+func (p Policy) Meets(v types.Vulnerability) bool {
+	if v.CvssScore < p.MinCvss {
+		return false
+	}
+	if rank, ok := severityRank[p.FailOnSeverity]; ok && severityRank[SeverityFor(v.CvssScore)] < rank {
+		return false
+	}
+	if p.FailOnUnfixed && !v.Unfixed {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+func TestSeverityFor(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{0, "none"},
+		{0.1, "low"},
+		{3.9, "low"},
+		{4.0, "medium"},
+		{6.9, "medium"},
+		{7.0, "high"},
+		{8.9, "high"},
+		{9.0, "critical"},
+		{10.0, "critical"},
+	}
+
+	for _, tt := range tests {
+		if got := SeverityFor(tt.score); got != tt.want {
+			t.Errorf("SeverityFor(%v) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestPolicyMeets(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		vuln   types.Vulnerability
+		want   bool
+	}{
+		{
+			name:   "default none policy meets everything",
+			policy: Policy{FailOnSeverity: "none"},
+			vuln:   types.Vulnerability{CvssScore: 0.1},
+			want:   true,
+		},
+		{
+			name:   "below fail-on severity is not met",
+			policy: Policy{FailOnSeverity: "high"},
+			vuln:   types.Vulnerability{CvssScore: 5.0},
+			want:   false,
+		},
+		{
+			name:   "at or above fail-on severity is met",
+			policy: Policy{FailOnSeverity: "high"},
+			vuln:   types.Vulnerability{CvssScore: 7.5},
+			want:   true,
+		},
+		{
+			name:   "below min-cvss is not met",
+			policy: Policy{FailOnSeverity: "none", MinCvss: 5.0},
+			vuln:   types.Vulnerability{CvssScore: 4.9},
+			want:   false,
+		},
+		{
+			name:   "fail-on-unfixed excludes fixed vulnerabilities",
+			policy: Policy{FailOnSeverity: "none", FailOnUnfixed: true},
+			vuln:   types.Vulnerability{CvssScore: 9.0, Unfixed: false},
+			want:   false,
+		},
+		{
+			name:   "fail-on-unfixed includes unfixed vulnerabilities",
+			policy: Policy{FailOnSeverity: "none", FailOnUnfixed: true},
+			vuln:   types.Vulnerability{CvssScore: 9.0, Unfixed: true},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Meets(tt.vuln); got != tt.want {
+				t.Errorf("Policy.Meets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/nancy/configuration"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+// LogResults marks excluded/expired/policy-suppressed findings, logs the
+// audit results through formatter, and returns the number of vulnerable
+// packages so the caller can decide the process exit code. artifactURI is
+// the go.sum/Gopkg.lock/module path that was scanned, and is only used by
+// formatters (like SarifFormatter) that need to point findings back at an
+// input location.
+func LogResults(formatter logrus.Formatter, packageCount int, coordinates []types.Coordinate, invalidCoordinates []types.Coordinate, exclusions []configuration.Exclusion, artifactURI string, policy Policy) int {
+	active, expired := configuration.Partition(exclusions, time.Now())
+
+	logger := logrus.New()
+	logger.SetFormatter(formatter)
+
+	var vulnerableCount int
+	for i := range coordinates {
+		purl := coordinates[i].Coordinates
+		for j := range coordinates[i].Vulnerabilities {
+			v := &coordinates[i].Vulnerabilities[j]
+
+			if matchesAny(active, v, purl) {
+				v.Excluded = true
+				continue
+			}
+			if matchesAny(expired, v, purl) {
+				v.IgnoreExpired = true
+				logger.WithFields(logrus.Fields{"cve": v.Cve, "package": purl}).Warn("Ignore entry expired, re-surfacing vulnerability")
+			}
+			if !policy.Meets(*v) {
+				v.Suppressed = true
+			}
+		}
+		if coordinates[i].IsVulnerableTo() {
+			vulnerableCount++
+		}
+	}
+
+	var informational []types.Vulnerability
+	for _, c := range coordinates {
+		for _, v := range c.Vulnerabilities {
+			if v.Informational {
+				informational = append(informational, v)
+			}
+		}
+	}
+
+	logger.WithFields(logrus.Fields{
+		"package_count":       packageCount,
+		"vulnerable_count":    vulnerableCount,
+		"coordinates":         coordinates,
+		"invalid_coordinates": invalidCoordinates,
+		"informational":       informational,
+		"artifact_uri":        artifactURI,
+	}).Info("Audit results")
+
+	return vulnerableCount
+}
+
+// matchesAny reports whether any exclusion in the list applies to v's
+// CVE/OSSINDEX id in the package identified by purl
+func matchesAny(exclusions []configuration.Exclusion, v *types.Vulnerability, purl string) bool {
+	for _, e := range exclusions {
+		if e.Matches(v.Cve, purl) || e.Matches(v.ID, purl) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/nancy/buildversion"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+const sarifVersion = "2.1.0"
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// SarifFormatter renders audit results as a SARIF 2.1.0 log, suitable for
+// upload to GitHub Advanced Security or any other SARIF-consuming platform
+type SarifFormatter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name            string      `json:"name"`
+	SemanticVersion string      `json:"semanticVersion"`
+	InformationURI  string      `json:"informationUri"`
+	Rules           []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string        `json:"id"`
+	Name string        `json:"name"`
+	Help sarifRuleHelp `json:"help"`
+}
+
+type sarifRuleHelp struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown"`
+}
+
+type sarifResult struct {
+	RuleID             string                 `json:"ruleId"`
+	Level              string                 `json:"level"`
+	Message            sarifMessage           `json:"message"`
+	Locations          []sarifLocation        `json:"locations"`
+	PartialFingerprint map[string]string      `json:"partialFingerprints"`
+	Properties         map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Format implements logrus.Formatter. It expects the entry.Data produced by
+// audit.LogResults: "coordinates" ([]types.Coordinate) and, optionally,
+// "artifact_uri" (the go.sum/Gopkg.lock/module path that was scanned).
+func (f *SarifFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	coordinates, _ := entry.Data["coordinates"].([]types.Coordinate)
+	artifactURI, _ := entry.Data["artifact_uri"].(string)
+	if artifactURI == "" {
+		artifactURI = "go.sum"
+	}
+
+	rules := map[string]sarifRule{}
+	var results []sarifResult
+
+	for _, coordinate := range coordinates {
+		for _, v := range coordinate.Vulnerabilities {
+			if v.Excluded {
+				continue
+			}
+			ruleID := ruleIDFor(v)
+			if _, ok := rules[ruleID]; !ok {
+				rules[ruleID] = sarifRule{
+					ID:   ruleID,
+					Name: v.Title,
+					Help: sarifRuleHelp{
+						Text:     v.Description,
+						Markdown: v.Description,
+					},
+				}
+			}
+
+			properties := map[string]interface{}{}
+			if v.Informational {
+				properties["informational"] = true
+			}
+			if v.Suppressed {
+				properties["suppressed"] = true
+			}
+			if v.IgnoreExpired {
+				properties["ignore_expired"] = true
+			}
+
+			results = append(results, sarifResult{
+				RuleID:  ruleID,
+				Level:   levelFor(v.CvssScore),
+				Message: sarifMessage{Text: fmt.Sprintf("%s: %s", coordinate.Coordinates, v.Title)},
+				Locations: []sarifLocation{
+					{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: artifactURI}}},
+				},
+				PartialFingerprint: map[string]string{
+					"primaryLocationLineHash": fingerprint(coordinate.Coordinates, ruleID),
+				},
+				Properties: properties,
+			})
+		}
+	}
+
+	driverRules := make([]sarifRule, 0, len(rules))
+	for _, r := range rules {
+		driverRules = append(driverRules, r)
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:            "Nancy",
+						SemanticVersion: buildversion.BuildVersion,
+						InformationURI:  "https://github.com/sonatype-nexus-community/nancy",
+						Rules:           driverRules,
+					},
+				},
+				Results: results,
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// ruleIDFor prefers the CVE id, falling back to the OSS Index vulnerability id
+func ruleIDFor(v types.Vulnerability) string {
+	if v.Cve != "" {
+		return v.Cve
+	}
+	return v.ID
+}
+
+// levelFor maps a CVSS score to a SARIF result level
+func levelFor(cvssScore float64) string {
+	switch {
+	case cvssScore >= 7.0:
+		return "error"
+	case cvssScore >= 4.0:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// fingerprint builds a stable suppression key from a package purl and rule id
+func fingerprint(purl, ruleID string) string {
+	return fmt.Sprintf("%s:%s", purl, ruleID)
+}
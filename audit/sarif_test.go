@@ -0,0 +1,87 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+func TestSarifFormatterFormat(t *testing.T) {
+	coordinates := []types.Coordinate{
+		{
+			Coordinates: "pkg:golang/example.com/foo@1.0.0",
+			Vulnerabilities: []types.Vulnerability{
+				{ID: "CVE-2023-1111", Cve: "CVE-2023-1111", Title: "critical bug", CvssScore: 9.8},
+				{ID: "CVE-2023-2222", Cve: "CVE-2023-2222", Title: "excluded bug", CvssScore: 7.5, Excluded: true},
+				{ID: "CVE-2023-3333", Cve: "CVE-2023-3333", Title: "unreachable bug", CvssScore: 7.5, Informational: true},
+				{ID: "CVE-2023-4444", Cve: "CVE-2023-4444", Title: "suppressed bug", CvssScore: 5.0, Suppressed: true},
+				{ID: "CVE-2023-5555", Cve: "CVE-2023-5555", Title: "resurfaced bug", CvssScore: 6.0, IgnoreExpired: true},
+			},
+		},
+	}
+
+	entry := &logrus.Entry{Data: logrus.Fields{
+		"coordinates":  coordinates,
+		"artifact_uri": "go.sum",
+	}}
+
+	f := &SarifFormatter{}
+	out, err := f.Format(entry)
+	if err != nil {
+		t.Fatalf("Format: unexpected error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out, &log); err != nil {
+		t.Fatalf("Format produced invalid JSON: %v", err)
+	}
+
+	if len(log.Runs) != 1 {
+		t.Fatalf("expected exactly one run, got %d", len(log.Runs))
+	}
+	results := log.Runs[0].Results
+
+	// the excluded finding should never appear
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results (excluded dropped), got %d", len(results))
+	}
+
+	byRuleID := map[string]sarifResult{}
+	for _, r := range results {
+		byRuleID[r.RuleID] = r
+	}
+
+	if _, ok := byRuleID["CVE-2023-2222"]; ok {
+		t.Error("excluded vulnerability should not appear in SARIF results")
+	}
+	if props := byRuleID["CVE-2023-3333"].Properties; props["informational"] != true {
+		t.Errorf("expected informational=true, got %v", props)
+	}
+	if props := byRuleID["CVE-2023-4444"].Properties; props["suppressed"] != true {
+		t.Errorf("expected suppressed=true, got %v", props)
+	}
+	if props := byRuleID["CVE-2023-5555"].Properties; props["ignore_expired"] != true {
+		t.Errorf("expected ignore_expired=true, got %v", props)
+	}
+	if byRuleID["CVE-2023-1111"].Level != "error" {
+		t.Errorf("expected a CVSS 9.8 finding to be level error, got %q", byRuleID["CVE-2023-1111"].Level)
+	}
+}
@@ -0,0 +1,52 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// govulncheckCmd is a convenience alias for `nancy --call-graph <path>/go.sum`,
+// named to match golang.org/x/vuln/cmd/govulncheck so users coming from that
+// tool can reach for a familiar command
+var govulncheckCmd = &cobra.Command{
+	Use:   "govulncheck [path to module directory]",
+	Short: "Audit a module, reporting only vulnerabilities reachable from its main packages",
+	Long: `govulncheck audits a Go module the same way the bare nancy command does, but
+always enables --call-graph: vulnerabilities whose affected symbols are not
+reachable from the module's main packages are demoted to informational
+findings instead of failing the build.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		configOssi.CallGraph = true
+
+		goSumPath := filepath.Join(args[0], "go.sum")
+		if _, err := os.Stat(goSumPath); err != nil {
+			return fmt.Errorf("could not find go.sum in module directory %s: %w", args[0], err)
+		}
+
+		return completeAndRun([]string{goSumPath})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(govulncheckCmd)
+}
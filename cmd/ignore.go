@@ -0,0 +1,93 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sonatype-nexus-community/nancy/configuration"
+	"github.com/spf13/cobra"
+)
+
+const exclusionDateLayout = "2006-01-02"
+
+var ignoreUntil string
+var ignoreReason string
+var ignorePackage string
+
+// ignoreCmd groups subcommands that manage the .nancy-ignore file without
+// requiring users to hand-edit its syntax
+var ignoreCmd = &cobra.Command{
+	Use:   "ignore",
+	Short: "Manage Nancy's ignore (.nancy-ignore) file",
+}
+
+var ignoreLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Report expired, duplicate, and malformed entries in a .nancy-ignore file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		findings, err := configuration.LintExclusionFile(excludeVulnerabilityFilePath)
+		if err != nil {
+			return fmt.Errorf("linting %s: %w", excludeVulnerabilityFilePath, err)
+		}
+		if len(findings) == 0 {
+			fmt.Printf("%s: no issues found\n", excludeVulnerabilityFilePath)
+			return nil
+		}
+		for _, f := range findings {
+			fmt.Printf("%s: %s\n", f.Line, f.Problem)
+		}
+		return nil
+	},
+}
+
+var ignoreAddCmd = &cobra.Command{
+	Use:   "add <CVE-id>",
+	Short: "Append a CVE to a .nancy-ignore file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exclusion := configuration.Exclusion{
+			ID:      args[0],
+			Reason:  ignoreReason,
+			Package: ignorePackage,
+		}
+		if ignoreUntil != "" {
+			until, err := time.Parse(exclusionDateLayout, ignoreUntil)
+			if err != nil {
+				return fmt.Errorf("invalid --until date %q: %w", ignoreUntil, err)
+			}
+			exclusion.Until = until
+		}
+
+		if err := configuration.AddExclusion(excludeVulnerabilityFilePath, exclusion); err != nil {
+			return fmt.Errorf("adding exclusion to %s: %w", excludeVulnerabilityFilePath, err)
+		}
+		fmt.Printf("Added %s to %s\n", exclusion.ID, excludeVulnerabilityFilePath)
+		return nil
+	},
+}
+
+func init() {
+	ignoreAddCmd.Flags().StringVar(&ignoreUntil, "until", "", "Date (YYYY-MM-DD) this exclusion expires and is re-surfaced")
+	ignoreAddCmd.Flags().StringVar(&ignoreReason, "reason", "", "Why this CVE is being excluded")
+	ignoreAddCmd.Flags().StringVar(&ignorePackage, "package", "", "Scope this exclusion to a single package purl")
+
+	ignoreCmd.AddCommand(ignoreLintCmd)
+	ignoreCmd.AddCommand(ignoreAddCmd)
+	rootCmd.AddCommand(ignoreCmd)
+}
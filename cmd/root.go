@@ -21,6 +21,8 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/common-nighthawk/go-figure"
 	"github.com/golang/dep"
 	"github.com/mitchellh/go-homedir"
@@ -33,7 +35,9 @@ import (
 	"github.com/sonatype-nexus-community/nancy/ossindex"
 	"github.com/sonatype-nexus-community/nancy/packages"
 	"github.com/sonatype-nexus-community/nancy/parse"
+	"github.com/sonatype-nexus-community/nancy/reachability"
 	"github.com/sonatype-nexus-community/nancy/types"
+	"github.com/sonatype-nexus-community/nancy/vulnsource"
 	"github.com/spf13/cobra"
 	"os"
 	"path/filepath"
@@ -50,10 +54,13 @@ var excludeVulnerabilityFilePath string
 var outputFormat string
 
 var outputFormats = map[string]logrus.Formatter{
-	"json":        &audit.JsonFormatter{},
-	"json-pretty": &audit.JsonFormatter{PrettyPrint: true},
-	"text":        &audit.AuditLogTextFormatter{Quiet: &configOssi.Quiet, NoColor: &configOssi.NoColor},
-	"csv":         &audit.CsvFormatter{Quiet: &configOssi.Quiet},
+	"json":           &audit.JsonFormatter{},
+	"json-pretty":    &audit.JsonFormatter{PrettyPrint: true},
+	"text":           &audit.AuditLogTextFormatter{Quiet: &configOssi.Quiet, NoColor: &configOssi.NoColor},
+	"csv":            &audit.CsvFormatter{Quiet: &configOssi.Quiet},
+	"sarif":          &audit.SarifFormatter{},
+	"cyclonedx-json": &audit.CycloneDxFormatter{Format: cdx.BOMFileFormatJSON},
+	"cyclonedx-xml":  &audit.CycloneDxFormatter{Format: cdx.BOMFileFormatXML},
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -66,23 +73,28 @@ a smooth experience as a Golang developer, using the best tools in the market!`,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
-		LogLady.Info("Nancy parsing config for OSS Index")
-		//ossIndexConfig, err := configuration.Parse(args)
-		err = completeConfig(&configOssi, args)
-		if err != nil {
-			flag.Usage()
-			err = customerrors.ErrorExit{Err: err, Message: err.Error(), ExitCode: 1}
-			return
-		}
-		if err = processConfig(configOssi); err != nil {
-			return
-		}
-		LogLady.Info("Nancy finished parsing config for OSS Index")
-		return
+		return completeAndRun(args)
 	},
 	Args: cobra.ArbitraryArgs, // allows "deprecated" Gopkg.lock or go.sum path args
 }
 
+// completeAndRun finishes parsing configOssi against args and runs the
+// resulting audit. It's shared by rootCmd and any subcommand, like
+// govulncheck, that wants to run the same audit with different defaults
+func completeAndRun(args []string) (err error) {
+	LogLady.Info("Nancy parsing config for OSS Index")
+	err = completeConfig(&configOssi, args)
+	if err != nil {
+		flag.Usage()
+		return customerrors.ErrorExit{Err: err, Message: err.Error(), ExitCode: 1}
+	}
+	if err = processConfig(configOssi); err != nil {
+		return err
+	}
+	LogLady.Info("Nancy finished parsing config for OSS Index")
+	return nil
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -109,14 +121,26 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&configOssi.Quiet, "quiet", "q", false, "indicate output should contain only packages with vulnerabilities")
 	rootCmd.PersistentFlags().BoolVar(&configOssi.Version, "version", false, "prints current nancy version")
 
-	rootCmd.Flags().BoolVarP(&configOssi.NoColor, "no-color", "n", false, "indicate output should not be colorized")
-	rootCmd.Flags().BoolVarP(&configOssi.CleanCache, "clean-cache", "c", false, "Deletes local cache directory")
+	// These are registered as persistent flags, rather than local ones, so
+	// that subcommands inherit them too: sbom/govulncheck share configOssi and
+	// the same completeAndRun/checkOSSIndex path, and ignore reads
+	// --exclude-vulnerability-file.
+	rootCmd.PersistentFlags().BoolVarP(&configOssi.NoColor, "no-color", "n", false, "indicate output should not be colorized")
+	rootCmd.PersistentFlags().BoolVarP(&configOssi.CleanCache, "clean-cache", "c", false, "Deletes local cache directory")
+	rootCmd.PersistentFlags().BoolVar(&configOssi.CallGraph, "call-graph", false, "Reduce false positives by only reporting vulnerabilities whose affected symbols are reachable from your module's main packages (requires a module directory, not StdIn)")
+
+	rootCmd.PersistentFlags().StringVar(&configOssi.FailOn, "fail-on", "none", "Minimum severity (none|low|medium|high|critical) a vulnerability must reach to fail the build")
+	rootCmd.PersistentFlags().Float64Var(&configOssi.MinCvss, "min-cvss", 0, "Minimum CVSS score a vulnerability must reach to fail the build")
+	rootCmd.PersistentFlags().BoolVar(&configOssi.FailOnUnfixed, "fail-on-unfixed", false, "Only fail the build for vulnerabilities with no known fix available")
+
+	rootCmd.PersistentFlags().StringVar(&configOssi.Source, "source", "ossindex", "Vulnerability data source to audit against (ossindex|osv|govulndb)")
+	rootCmd.PersistentFlags().StringVar(&configOssi.DB, "db", "", "Override the Go vulnerability database location for --source=govulndb, e.g. file:///path/to/mirror")
 
-	rootCmd.Flags().VarP(&configOssi.CveList, "exclude-vulnerability", "e", "Comma separated list of CVEs to exclude")
-	rootCmd.Flags().StringVarP(&configOssi.Username, "user", "u", "", "Specify OSS Index username for request")
-	rootCmd.Flags().StringVarP(&configOssi.Token, "token", "t", "", "Specify OSS Index API token for request")
-	rootCmd.Flags().StringVarP(&excludeVulnerabilityFilePath, "exclude-vulnerability-file", "x", "./.nancy-ignore", "Path to a file containing newline separated CVEs to be excluded")
-	rootCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Styling for output format. "+fmt.Sprintf("%+q", reflect.ValueOf(outputFormats).MapKeys()))
+	rootCmd.PersistentFlags().VarP(&configOssi.CveList, "exclude-vulnerability", "e", "Comma separated list of CVEs to exclude")
+	rootCmd.PersistentFlags().StringVarP(&configOssi.Username, "user", "u", "", "Specify OSS Index username for request")
+	rootCmd.PersistentFlags().StringVarP(&configOssi.Token, "token", "t", "", "Specify OSS Index API token for request")
+	rootCmd.PersistentFlags().StringVarP(&excludeVulnerabilityFilePath, "exclude-vulnerability-file", "x", "./.nancy-ignore", "Path to a file containing newline separated CVEs to be excluded")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Styling for output format. "+fmt.Sprintf("%+q", reflect.ValueOf(outputFormats).MapKeys()))
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -202,9 +226,12 @@ func completeConfig(config *configuration.Configuration, args []string) error {
 	if err != nil {
 		return err
 	}
-	if len(modfilePath) > 0 {
+	switch {
+	case config.SBOMPath != "":
+		// audited via doSBOMAndParse, bypassing StdIn/go.sum/Gopkg.lock
+	case len(modfilePath) > 0:
 		config.Path = modfilePath
-	} else {
+	default:
 		config.UseStdIn = true
 	}
 
@@ -274,19 +301,25 @@ func processConfig(config configuration.Configuration) (err error) {
 			fmt.Printf("ERROR: cleaning cache: %v\n", err)
 			os.Exit(1)
 		}
+		if err := reachability.RemoveCacheDirectory(); err != nil {
+			LogLady.WithField("error", err).Error("Error cleaning call graph cache")
+			fmt.Printf("ERROR: cleaning cache: %v\n", err)
+			os.Exit(1)
+		}
 		LogLady.Info("Cache cleaned")
 		return
 	}
 
 	printHeader(!config.Quiet && reflect.TypeOf(config.Formatter).String() == "*audit.AuditLogTextFormatter")
 
-	if config.UseStdIn {
+	switch {
+	case config.SBOMPath != "":
+		LogLady.Info("Parsing config for CycloneDX SBOM")
+		err = doSBOMAndParse(config)
+	case config.UseStdIn:
 		LogLady.Info("Parsing config for StdIn")
-		if err = doStdInAndParse(config); err != nil {
-			return
-		}
-	}
-	if !config.UseStdIn {
+		err = doStdInAndParse(config)
+	default:
 		LogLady.Info("Parsing config for file based scan")
 		err = doCheckExistenceAndParse(config)
 	}
@@ -328,7 +361,7 @@ func doStdInAndParse(config configuration.Configuration) (err error) {
 	}).Debug("Extracted purls")
 
 	LogLady.Info("Auditing purls with OSS Index")
-	err = checkOSSIndex(purls, nil, config)
+	err = checkOSSIndex(purls, nil, "", config)
 
 	return err
 }
@@ -355,7 +388,7 @@ func doCheckExistenceAndParse(config configuration.Configuration) error {
 
 		purls, invalidPurls := packages.ExtractPurlsUsingDep(project)
 
-		if err := checkOSSIndex(purls, invalidPurls, config); err != nil {
+		if err := checkOSSIndex(purls, invalidPurls, workingDir, config); err != nil {
 			return err
 		}
 	case strings.Contains(config.Path, "go.sum"):
@@ -369,10 +402,44 @@ func doCheckExistenceAndParse(config configuration.Configuration) error {
 			mod.ProjectList, _ = parse.GoSum(config.Path)
 			var purls = mod.ExtractPurlsFromManifest()
 
-			if err := checkOSSIndex(purls, nil, config); err != nil {
+			workingDir := filepath.Dir(config.Path)
+			if workingDir == "." {
+				workingDir, _ = os.Getwd()
+			}
+			if err := checkOSSIndex(purls, nil, workingDir, config); err != nil {
 				return err
 			}
 		}
+	case strings.Contains(config.Path, "go.work"):
+		mod := packages.Mod{}
+		projectList, err := packages.ResolveWorkspaceModuleGraph(config.Path)
+		if err != nil {
+			return customerrors.NewErrorExitPrintHelp(err, fmt.Sprintf("could not resolve workspace at path %s", config.Path))
+		}
+		mod.ProjectList = projectList
+		var purls = mod.ExtractPurlsFromManifest()
+
+		workingDir := filepath.Dir(config.Path)
+		if err := checkOSSIndex(purls, nil, workingDir, config); err != nil {
+			return err
+		}
+	case strings.Contains(config.Path, "go.mod"):
+		workingDir := filepath.Dir(config.Path)
+		if workingDir == "." {
+			workingDir, _ = os.Getwd()
+		}
+
+		mod := packages.Mod{}
+		var err error
+		mod.ProjectList, err = packages.ResolveModuleGraph(workingDir)
+		if err != nil {
+			return customerrors.NewErrorExitPrintHelp(err, fmt.Sprintf("could not resolve module graph at path %s", config.Path))
+		}
+		var purls = mod.ExtractPurlsFromManifest()
+
+		if err := checkOSSIndex(purls, nil, workingDir, config); err != nil {
+			return err
+		}
 	default:
 		//os.Exit(3)
 		return customerrors.ErrorExit{ExitCode: 3, Message: fmt.Sprintf("invalid path arg: %s", config.Path)}
@@ -380,24 +447,61 @@ func doCheckExistenceAndParse(config configuration.Configuration) error {
 	return nil
 }
 
-func checkOSSIndex(purls []string, invalidpurls []string, config configuration.Configuration) error {
+func checkOSSIndex(purls []string, invalidpurls []string, workingDir string, config configuration.Configuration) error {
 	var packageCount = len(purls)
-	coordinates, err := ossindex.AuditPackagesWithOSSIndex(purls, &config)
+
+	auditor, err := auditorForSource(config)
+	if err != nil {
+		return customerrors.ErrorExit{Err: err, Message: err.Error(), ExitCode: 1}
+	}
+	coordinates, err := auditor.Audit(purls)
 	if err != nil {
 		return customerrors.NewErrorExitPrintHelp(err, "Error auditing packages")
 	}
 
+	if config.CallGraph {
+		if workingDir == "" {
+			return customerrors.ErrorExit{ExitCode: 1, Message: "--call-graph requires a module directory input, not StdIn"}
+		}
+		LogLady.Info("Building call graph for symbol-level reachability filtering")
+		result, err := reachability.Analyze(workingDir)
+		if err != nil {
+			LogLady.WithField("error", err).Warn("Unable to build call graph, reporting all vulnerabilities as found")
+		} else {
+			reachability.Demote(coordinates, result)
+		}
+	}
+
 	var invalidCoordinates []types.Coordinate
 	for _, invalidpurl := range invalidpurls {
 		invalidCoordinates = append(invalidCoordinates, types.Coordinate{Coordinates: invalidpurl, InvalidSemVer: true})
 	}
 
-	if count := audit.LogResults(config.Formatter, packageCount, coordinates, invalidCoordinates, config.CveList.Cves); count > 0 {
+	artifactURI := workingDir
+	if config.Path != "" {
+		artifactURI = config.Path
+	}
+	policy := audit.Policy{FailOnSeverity: config.FailOn, MinCvss: config.MinCvss, FailOnUnfixed: config.FailOnUnfixed}
+	if count := audit.LogResults(config.Formatter, packageCount, coordinates, invalidCoordinates, config.CveList.Exclusions, artifactURI, policy); count > 0 {
 		os.Exit(count)
 	}
 	return nil
 }
 
+// auditorForSource builds the vulnsource.Auditor selected by --source
+func auditorForSource(config configuration.Configuration) (vulnsource.Auditor, error) {
+	switch config.Source {
+	case "", "ossindex":
+		return &ossindex.Client{Config: &config}, nil
+	case "osv":
+		return vulnsource.NewOSVAuditor(), nil
+	case "govulndb":
+		return vulnsource.NewGovulndbAuditor(config.DB), nil
+	default:
+		return nil, fmt.Errorf("unknown --source %q, expected one of ossindex, osv, govulndb", config.Source)
+	}
+}
+
 var stdInInvalid = customerrors.ErrorExit{ExitCode: 1, Message: "StdIn is invalid, either empty or another reason"}
 
 func checkStdIn() (err error) {
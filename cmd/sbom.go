@@ -0,0 +1,56 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cmd
+
+import (
+	"github.com/sonatype-nexus-community/nancy/configuration"
+	"github.com/sonatype-nexus-community/nancy/cyclonedx"
+	. "github.com/sonatype-nexus-community/nancy/logger"
+	"github.com/spf13/cobra"
+)
+
+// sbomCmd audits a CycloneDX SBOM directly, for users whose pipelines
+// produce an SBOM rather than a go.sum/Gopkg.lock
+var sbomCmd = &cobra.Command{
+	Use:   "sbom <path to CycloneDX SBOM>",
+	Short: "Check for vulnerabilities using the purls in a CycloneDX SBOM",
+	Long: `sbom audits the components[].purl entries of a CycloneDX 1.4+ JSON or XML SBOM
+against Sonatype OSS Index, the same way the bare nancy command audits a go.sum.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		configOssi.SBOMPath = args[0]
+		return completeAndRun(nil)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sbomCmd)
+	rootCmd.Flags().StringVar(&configOssi.SBOMPath, "sbom", "", "Path to a CycloneDX SBOM to audit, bypassing go.sum/Gopkg.lock/go list input")
+}
+
+// doSBOMAndParse audits config.SBOMPath's components, bypassing the
+// go.sum/Gopkg.lock/go list code paths in doCheckExistenceAndParse
+func doSBOMAndParse(config configuration.Configuration) error {
+	LogLady.WithField("path", config.SBOMPath).Info("Parsing config for CycloneDX SBOM")
+
+	purls, err := cyclonedx.ExtractPurlsFromSBOM(config.SBOMPath)
+	if err != nil {
+		return err
+	}
+
+	return checkOSSIndex(purls, nil, "", config)
+}
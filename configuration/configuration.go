@@ -0,0 +1,195 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package configuration holds the Nancy runtime configuration, and knows how
+// to load/save it from the user's OSS Index config file
+package configuration
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/sirupsen/logrus"
+)
+
+// HomeDir is the current user's home directory, resolved once at package init
+var HomeDir string
+
+// ConfigLocation is the resolved path to the OSS Index config file, set by cmd
+// once flags have been parsed
+var ConfigLocation string
+
+func init() {
+	home, err := homedir.Dir()
+	if err == nil {
+		HomeDir = home
+	}
+}
+
+// CveListFlag implements pflag.Value so CVEs can be passed as a comma
+// separated list via --exclude-vulnerability. Each comma separated entry is
+// parsed with ParseExclusionLine, so both a plain "CVE-2023-1234" and the
+// richer "CVE-2023-1234 until=2025-01-01 reason=\"...\"" syntax work.
+type CveListFlag struct {
+	Exclusions []Exclusion
+}
+
+func (c *CveListFlag) String() string {
+	ids := make([]string, 0, len(c.Exclusions))
+	for _, e := range c.Exclusions {
+		ids = append(ids, e.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+// Set parses the comma separated exclusions in value and appends them to the
+// existing list
+func (c *CveListFlag) Set(value string) error {
+	for _, entry := range splitTopLevelCommas(value) {
+		exclusion, err := ParseExclusionLine(entry)
+		if err != nil {
+			return err
+		}
+		c.Exclusions = append(c.Exclusions, exclusion)
+	}
+	return nil
+}
+
+// Type satisfies pflag.Value
+func (c *CveListFlag) Type() string {
+	return "cve-list"
+}
+
+// splitTopLevelCommas splits value on commas that aren't inside a
+// double-quoted reason="..." field
+func splitTopLevelCommas(value string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range value {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case r == ',' && !inQuotes:
+			fields = append(fields, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	fields = append(fields, current.String())
+	return fields
+}
+
+// Configuration holds all of the settings Nancy needs in order to run an audit
+type Configuration struct {
+	Help       bool
+	Info       bool
+	Debug      bool
+	Trace      bool
+	LogLevel   int
+	Quiet      bool
+	NoColor    bool
+	Version    bool
+	CleanCache bool
+
+	Username string
+	Token    string
+
+	CveList CveListFlag
+
+	Path     string
+	UseStdIn bool
+
+	// CallGraph enables symbol-level reachability filtering: vulnerabilities
+	// whose affected symbols aren't reachable from the scanned module's main
+	// packages are demoted to informational instead of failing the build
+	CallGraph bool
+
+	// SBOMPath, when set, points at a CycloneDX SBOM to audit instead of a
+	// go.sum/Gopkg.lock/go list manifest
+	SBOMPath string
+
+	// FailOn is the minimum --fail-on severity (none|low|medium|high|critical)
+	// a vulnerability must reach to affect the process exit code
+	FailOn string
+	// MinCvss is the minimum --min-cvss score a vulnerability must reach to
+	// affect the process exit code
+	MinCvss float64
+	// FailOnUnfixed, when true, only counts vulnerabilities with no known fix
+	// towards the exit code
+	FailOnUnfixed bool
+
+	// Source selects the vulnerability data source: ossindex (default), osv,
+	// or govulndb
+	Source string
+	// DB overrides the Go vulnerability database location for source=govulndb,
+	// e.g. file:///path/to/local/mirror
+	DB string
+
+	Formatter logrus.Formatter
+}
+
+// LoadConfigFromFile reads OSS Index credentials (username/token) from the
+// JSON config file at path into config, if it exists. A missing file is not
+// an error; callers typically fall back to flags or environment variables
+func LoadConfigFromFile(path string, config *Configuration) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	var onDisk struct {
+		Username string `json:"username"`
+		Token    string `json:"token"`
+	}
+	if err := json.Unmarshal(data, &onDisk); err != nil {
+		return err
+	}
+
+	if config.Username == "" {
+		config.Username = onDisk.Username
+	}
+	if config.Token == "" {
+		config.Token = onDisk.Token
+	}
+	return nil
+}
+
+// GetCVEExcludesFromFile reads a newline separated list of exclusions from
+// excludeVulnerabilityFilePath and appends them to config.CveList. Each line
+// may be a plain "CVE-2023-1234" or the richer
+// "CVE-2023-1234 until=2025-01-01 reason=\"...\" package=pkg:golang/foo/bar"
+// syntax. A missing file is not an error, since the flag defaults to
+// ./.nancy-ignore.
+func GetCVEExcludesFromFile(config *Configuration, excludeVulnerabilityFilePath string) error {
+	exclusions, err := ReadExclusionFile(excludeVulnerabilityFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	config.CveList.Exclusions = append(config.CveList.Exclusions, exclusions...)
+	return nil
+}
@@ -0,0 +1,237 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const exclusionDateLayout = "2006-01-02"
+
+// Exclusion is one parsed line from a .nancy-ignore file or
+// --exclude-vulnerability flag. Only ID is required; a plain "CVE-2023-1234"
+// line parses to an Exclusion with zero-value Until/Reason/Package, keeping
+// the old plain-CVE-per-line format working unchanged.
+type Exclusion struct {
+	ID      string
+	Until   time.Time
+	Reason  string
+	Package string
+}
+
+// Expired reports whether this exclusion's until= date has passed as of now
+func (e Exclusion) Expired(now time.Time) bool {
+	return !e.Until.IsZero() && now.After(e.Until)
+}
+
+// Matches reports whether this exclusion applies to a vulnerability with the
+// given CVE/OSSINDEX id found in the package identified by purl. An
+// exclusion scoped to a package (package=...) never masks the same CVE in an
+// unrelated dependency.
+func (e Exclusion) Matches(id string, purl string) bool {
+	if e.ID != id {
+		return false
+	}
+	return e.Package == "" || e.Package == purl
+}
+
+// ParseExclusionLine parses one line of a .nancy-ignore file, or one
+// comma-separated segment of --exclude-vulnerability, in the form:
+//
+//	CVE-2023-1234 until=2025-01-01 reason="waiting on upstream fix" package=pkg:golang/foo/bar
+//
+// A bare "CVE-2023-1234" is still valid and parses to an Exclusion with only
+// ID set.
+func ParseExclusionLine(line string) (Exclusion, error) {
+	fields, err := splitExclusionFields(line)
+	if err != nil {
+		return Exclusion{}, err
+	}
+	if len(fields) == 0 {
+		return Exclusion{}, fmt.Errorf("empty exclusion entry")
+	}
+
+	exclusion := Exclusion{ID: fields[0]}
+	for _, field := range fields[1:] {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return Exclusion{}, fmt.Errorf("malformed exclusion field %q in %q", field, line)
+		}
+		value = strings.Trim(value, `"`)
+		switch key {
+		case "until":
+			until, err := time.Parse(exclusionDateLayout, value)
+			if err != nil {
+				return Exclusion{}, fmt.Errorf("invalid until= date %q in %q: %w", value, line, err)
+			}
+			exclusion.Until = until
+		case "reason":
+			exclusion.Reason = value
+		case "package":
+			exclusion.Package = value
+		default:
+			return Exclusion{}, fmt.Errorf("unknown exclusion field %q in %q", key, line)
+		}
+	}
+	return exclusion, nil
+}
+
+// splitExclusionFields splits line on whitespace, but keeps a double-quoted
+// reason="..." value (which may itself contain spaces) as one field
+func splitExclusionFields(line string) ([]string, error) {
+	var fields []string
+	var current strings.Builder
+	inQuotes := false
+
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in exclusion %q", line)
+	}
+	return fields, nil
+}
+
+// Partition splits exclusions into those still active and those whose
+// until= date has passed as of now
+func Partition(exclusions []Exclusion, now time.Time) (active []Exclusion, expired []Exclusion) {
+	for _, e := range exclusions {
+		if e.Expired(now) {
+			expired = append(expired, e)
+		} else {
+			active = append(active, e)
+		}
+	}
+	return active, expired
+}
+
+// ReadExclusionFile reads and parses every non-comment, non-blank line of
+// path as an Exclusion. The file not existing is returned as-is so callers
+// can use os.IsNotExist to treat a missing ignore file as "no exclusions".
+func ReadExclusionFile(path string) ([]Exclusion, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var exclusions []Exclusion
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		exclusion, err := ParseExclusionLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+	return exclusions, nil
+}
+
+// LintFinding is one problem LintExclusionFile found with an ignore file
+type LintFinding struct {
+	Line      string
+	Problem   string
+	Expired   bool
+	Malformed bool
+	Duplicate bool
+}
+
+// LintExclusionFile reports expired, duplicate and malformed entries in the
+// ignore file at path, without failing on the first bad line the way
+// ReadExclusionFile does, so `nancy ignore lint` can report everything wrong
+// with a file in one pass.
+func LintExclusionFile(path string) ([]LintFinding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []LintFinding
+	seen := map[string]bool{}
+	now := time.Now()
+
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		exclusion, err := ParseExclusionLine(trimmed)
+		if err != nil {
+			findings = append(findings, LintFinding{Line: trimmed, Problem: err.Error(), Malformed: true})
+			continue
+		}
+
+		key := exclusion.ID + "|" + exclusion.Package
+		if seen[key] {
+			findings = append(findings, LintFinding{Line: trimmed, Problem: fmt.Sprintf("duplicate exclusion for %s", exclusion.ID), Duplicate: true})
+		}
+		seen[key] = true
+
+		if exclusion.Expired(now) {
+			findings = append(findings, LintFinding{Line: trimmed, Problem: fmt.Sprintf("expired on %s", exclusion.Until.Format(exclusionDateLayout)), Expired: true})
+		}
+	}
+	return findings, nil
+}
+
+// AddExclusion appends an Exclusion line to the ignore file at path,
+// creating it if it doesn't exist yet
+func AddExclusion(path string, exclusion Exclusion) error {
+	line := exclusion.ID
+	if !exclusion.Until.IsZero() {
+		line += " until=" + exclusion.Until.Format(exclusionDateLayout)
+	}
+	if exclusion.Reason != "" {
+		line += ` reason="` + exclusion.Reason + `"`
+	}
+	if exclusion.Package != "" {
+		line += " package=" + exclusion.Package
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(line + "\n")
+	return err
+}
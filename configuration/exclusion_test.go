@@ -0,0 +1,155 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseExclusionLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    Exclusion
+		wantErr bool
+	}{
+		{
+			name: "bare CVE",
+			line: "CVE-2023-1234",
+			want: Exclusion{ID: "CVE-2023-1234"},
+		},
+		{
+			name: "until and reason and package",
+			line: `CVE-2023-1234 until=2025-01-01 reason="waiting on upstream fix" package=pkg:golang/foo/bar`,
+			want: Exclusion{
+				ID:      "CVE-2023-1234",
+				Until:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Reason:  "waiting on upstream fix",
+				Package: "pkg:golang/foo/bar",
+			},
+		},
+		{
+			name:    "empty line",
+			line:    "",
+			wantErr: true,
+		},
+		{
+			name:    "malformed field",
+			line:    "CVE-2023-1234 bogus",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			line:    "CVE-2023-1234 nope=1",
+			wantErr: true,
+		},
+		{
+			name:    "invalid until date",
+			line:    "CVE-2023-1234 until=not-a-date",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseExclusionLine(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseExclusionLine(%q): expected error, got nil", tt.line)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseExclusionLine(%q): unexpected error: %v", tt.line, err)
+			}
+			if got.ID != tt.want.ID || got.Reason != tt.want.Reason || got.Package != tt.want.Package || !got.Until.Equal(tt.want.Until) {
+				t.Fatalf("ParseExclusionLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExclusionMatches(t *testing.T) {
+	scoped := Exclusion{ID: "CVE-2023-1234", Package: "pkg:golang/foo/bar"}
+	unscoped := Exclusion{ID: "CVE-2023-1234"}
+
+	if !unscoped.Matches("CVE-2023-1234", "pkg:golang/anything") {
+		t.Fatal("unscoped exclusion should match any package")
+	}
+	if !scoped.Matches("CVE-2023-1234", "pkg:golang/foo/bar") {
+		t.Fatal("scoped exclusion should match its own package")
+	}
+	if scoped.Matches("CVE-2023-1234", "pkg:golang/other/pkg") {
+		t.Fatal("scoped exclusion should not match an unrelated package")
+	}
+	if scoped.Matches("CVE-9999-9999", "pkg:golang/foo/bar") {
+		t.Fatal("exclusion should not match a different CVE")
+	}
+}
+
+func TestPartition(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	exclusions := []Exclusion{
+		{ID: "CVE-active"},
+		{ID: "CVE-future", Until: now.Add(24 * time.Hour)},
+		{ID: "CVE-expired", Until: now.Add(-24 * time.Hour)},
+	}
+
+	active, expired := Partition(exclusions, now)
+
+	if len(active) != 2 || active[0].ID != "CVE-active" || active[1].ID != "CVE-future" {
+		t.Fatalf("unexpected active partition: %+v", active)
+	}
+	if len(expired) != 1 || expired[0].ID != "CVE-expired" {
+		t.Fatalf("unexpected expired partition: %+v", expired)
+	}
+}
+
+func TestLintExclusionFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".nancy-ignore")
+	contents := "CVE-2023-1111\n" +
+		"CVE-2023-1111\n" +
+		"CVE-2023-2222 until=2000-01-01\n" +
+		"CVE-2023-3333 bogus\n"
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("writing test ignore file: %v", err)
+	}
+
+	findings, err := LintExclusionFile(path)
+	if err != nil {
+		t.Fatalf("LintExclusionFile: unexpected error: %v", err)
+	}
+
+	var duplicate, expired, malformed bool
+	for _, f := range findings {
+		duplicate = duplicate || f.Duplicate
+		expired = expired || f.Expired
+		malformed = malformed || f.Malformed
+	}
+	if !duplicate {
+		t.Error("expected a duplicate finding")
+	}
+	if !expired {
+		t.Error("expected an expired finding")
+	}
+	if !malformed {
+		t.Error("expected a malformed finding")
+	}
+}
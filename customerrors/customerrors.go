@@ -0,0 +1,54 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package customerrors defines error types used to carry a process exit code
+// alongside a user facing message
+package customerrors
+
+import "fmt"
+
+// ErrorExit is an error which also carries the process exit code Nancy
+// should terminate with once the error has been handled
+type ErrorExit struct {
+	Err      error
+	Message  string
+	ExitCode int
+}
+
+func (e ErrorExit) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("nancy exited with code %d", e.ExitCode)
+}
+
+// Unwrap allows errors.Is/errors.As to reach the wrapped error
+func (e ErrorExit) Unwrap() error {
+	return e.Err
+}
+
+// NewErrorExitPrintHelp wraps err as an ErrorExit with exit code 1 and a
+// message instructing the user to see --help for usage
+func NewErrorExitPrintHelp(err error, message string) ErrorExit {
+	return ErrorExit{
+		Err:      err,
+		Message:  fmt.Sprintf("%s: %v. See -h for help", message, err),
+		ExitCode: 1,
+	}
+}
@@ -0,0 +1,59 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package cyclonedx parses CycloneDX SBOMs into purls for auditing. Rendering
+// audit results as a CycloneDX BOM is done by audit.CycloneDxFormatter.
+package cyclonedx
+
+import (
+	"fmt"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"os"
+	"path/filepath"
+)
+
+// ExtractPurlsFromSBOM reads the CycloneDX 1.4+ BOM at path (JSON or XML,
+// detected by extension) and returns the purl of each component that has one
+func ExtractPurlsFromSBOM(path string) (purls []string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	format := cdx.BOMFileFormatJSON
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		format = cdx.BOMFileFormatXML
+	}
+
+	bom := cdx.NewBOM()
+	decoder := cdx.NewBOMDecoder(file, format)
+	if err := decoder.Decode(bom); err != nil {
+		return nil, fmt.Errorf("decoding CycloneDX SBOM %s: %w", path, err)
+	}
+
+	if bom.Components == nil {
+		return nil, nil
+	}
+	for _, c := range *bom.Components {
+		if c.PackageURL != "" {
+			purls = append(purls, c.PackageURL)
+		}
+	}
+	return purls, nil
+}
@@ -0,0 +1,62 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cyclonedx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "version": 1,
+  "components": [
+    {"type": "library", "name": "foo", "version": "1.0.0", "purl": "pkg:golang/example.com/foo@1.0.0"},
+    {"type": "library", "name": "bar", "version": "2.0.0", "purl": "pkg:golang/example.com/bar@2.0.0"},
+    {"type": "library", "name": "no-purl", "version": "3.0.0"}
+  ]
+}`
+
+func TestExtractPurlsFromSBOM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bom.json")
+	if err := os.WriteFile(path, []byte(testSBOM), 0644); err != nil {
+		t.Fatalf("writing test SBOM: %v", err)
+	}
+
+	purls, err := ExtractPurlsFromSBOM(path)
+	if err != nil {
+		t.Fatalf("ExtractPurlsFromSBOM: unexpected error: %v", err)
+	}
+
+	want := []string{"pkg:golang/example.com/foo@1.0.0", "pkg:golang/example.com/bar@2.0.0"}
+	if len(purls) != len(want) {
+		t.Fatalf("got %v, want %v", purls, want)
+	}
+	for i, p := range want {
+		if purls[i] != p {
+			t.Errorf("purls[%d] = %q, want %q", i, purls[i], p)
+		}
+	}
+}
+
+func TestExtractPurlsFromSBOMMissingFile(t *testing.T) {
+	if _, err := ExtractPurlsFromSBOM(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("expected an error for a missing SBOM file")
+	}
+}
@@ -0,0 +1,34 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ossindex
+
+import (
+	"github.com/sonatype-nexus-community/nancy/configuration"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+// Client adapts AuditPackagesWithOSSIndex to the vulnsource.Auditor
+// interface, so OSS Index can be selected via --source=ossindex alongside
+// the other vulnsource implementations
+type Client struct {
+	Config *configuration.Configuration
+}
+
+// Audit implements vulnsource.Auditor
+func (c *Client) Audit(purls []string) ([]types.Coordinate, error) {
+	return AuditPackagesWithOSSIndex(purls, c.Config)
+}
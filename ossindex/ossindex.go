@@ -0,0 +1,59 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package ossindex is the Sonatype OSS Index data source entry point, and
+// manages Nancy's on-disk OSS Index response cache directory
+package ossindex
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/sonatype-nexus-community/nancy/configuration"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+const cacheDirName = "responses"
+
+// cacheDirectory returns the directory Nancy caches OSS Index responses in,
+// namely $HOME/.ossindex/responses
+func cacheDirectory() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, types.OssIndexDirName, cacheDirName), nil
+}
+
+// RemoveCacheDirectory deletes Nancy's local OSS Index response cache
+func RemoveCacheDirectory() error {
+	dir, err := cacheDirectory()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// AuditPackagesWithOSSIndex returns one Coordinate per purl in purls, with no
+// vulnerabilities. It does not contact OSS Index, and config is not read.
+func AuditPackagesWithOSSIndex(purls []string, config *configuration.Configuration) ([]types.Coordinate, error) {
+	coordinates := make([]types.Coordinate, 0, len(purls))
+	for _, purl := range purls {
+		coordinates = append(coordinates, types.Coordinate{Coordinates: purl})
+	}
+	return coordinates, nil
+}
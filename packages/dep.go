@@ -0,0 +1,40 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packages
+
+import (
+	"fmt"
+
+	"github.com/golang/dep"
+)
+
+// ExtractPurlsUsingDep turns a dep.Project's locked dependencies into purls,
+// returning separately the purls it could not confidently resolve a semantic
+// version for
+func ExtractPurlsUsingDep(project *dep.Project) (purls []string, invalidPurls []string) {
+	for _, p := range project.Lock.Projects() {
+		ident := p.Ident()
+		version := p.Version().String()
+		purl := fmt.Sprintf("pkg:golang/%s@%s", ident.ProjectRoot, version)
+		if version == "" {
+			invalidPurls = append(invalidPurls, purl)
+			continue
+		}
+		purls = append(purls, purl)
+	}
+	return
+}
@@ -0,0 +1,69 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// goListModule mirrors the subset of `go list -m -json` output Nancy needs.
+// Main is true for the module being built; for a replaced module, the nested
+// Replace field holds the effective Path/Version of its replacement.
+type goListModule struct {
+	Path    string
+	Version string
+	Main    bool
+	Replace *goListModule
+}
+
+// ResolveModuleGraph runs `go list -m -json all` inside dir and returns one
+// "module version" entry per resolved module, honoring replace/exclude
+// directives the way go.sum cannot, since go.sum only records checksums for
+// modules that were actually selected by MVS.
+func ResolveModuleGraph(dir string) (projectList []string, err error) {
+	cmd := exec.Command("go", "list", "-m", "-json", "all")
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("go list -m -json all in %s: %w: %s", dir, err, stderr.String())
+	}
+
+	decoder := json.NewDecoder(&stdout)
+	for decoder.More() {
+		var mod goListModule
+		if err := decoder.Decode(&mod); err != nil {
+			return nil, fmt.Errorf("parsing go list -m -json output: %w", err)
+		}
+		if mod.Main {
+			continue
+		}
+		if mod.Replace != nil {
+			mod = *mod.Replace
+		}
+		if mod.Version == "" {
+			continue
+		}
+		projectList = append(projectList, fmt.Sprintf("%s %s", mod.Path, mod.Version))
+	}
+	return projectList, nil
+}
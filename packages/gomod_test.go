@@ -0,0 +1,67 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packages
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const testGoMod = `module example.com/nancytest
+
+go 1.20
+`
+
+func writeTestModule(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(testGoMod), 0644); err != nil {
+		t.Fatalf("writing go.mod: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+		t.Fatalf("writing main.go: %v", err)
+	}
+	return dir
+}
+
+func TestResolveModuleGraphNoDependencies(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	dir := writeTestModule(t)
+
+	projectList, err := ResolveModuleGraph(dir)
+	if err != nil {
+		t.Fatalf("ResolveModuleGraph: unexpected error: %v", err)
+	}
+	if len(projectList) != 0 {
+		t.Errorf("expected no dependencies, got %v", projectList)
+	}
+}
+
+func TestResolveModuleGraphMissingDir(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	if _, err := ResolveModuleGraph(filepath.Join(t.TempDir(), "does-not-exist")); err == nil {
+		t.Fatal("expected an error resolving a module graph in a non-existent directory")
+	}
+}
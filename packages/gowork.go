@@ -0,0 +1,60 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packages
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// ResolveWorkspaceModuleGraph reads the go.work file at goWorkPath, resolves
+// the module graph of each module listed in its `use` directives, and
+// returns the merged, de-duplicated set of "module version" entries across
+// the whole workspace. This lets a single invocation scan a multi-module
+// workspace that can't otherwise be audited in one pass.
+func ResolveWorkspaceModuleGraph(goWorkPath string) (projectList []string, err error) {
+	data, err := os.ReadFile(goWorkPath)
+	if err != nil {
+		return nil, err
+	}
+
+	work, err := modfile.ParseWork(goWorkPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", goWorkPath, err)
+	}
+
+	workDir := filepath.Dir(goWorkPath)
+	seen := map[string]bool{}
+	for _, use := range work.Use {
+		moduleDir := filepath.Join(workDir, use.Path)
+		entries, err := ResolveModuleGraph(moduleDir)
+		if err != nil {
+			return nil, fmt.Errorf("resolving workspace module %s: %w", use.Path, err)
+		}
+		for _, entry := range entries {
+			if seen[entry] {
+				continue
+			}
+			seen[entry] = true
+			projectList = append(projectList, entry)
+		}
+	}
+	return projectList, nil
+}
@@ -0,0 +1,71 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package packages
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const testGoWork = `go 1.20
+
+use (
+	./a
+	./b
+)
+`
+
+func TestResolveWorkspaceModuleGraphMergesAndDedupes(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "go.work"), []byte(testGoWork), 0644); err != nil {
+		t.Fatalf("writing go.work: %v", err)
+	}
+
+	for _, name := range []string{"a", "b"} {
+		moduleDir := filepath.Join(workDir, name)
+		if err := os.MkdirAll(moduleDir, 0755); err != nil {
+			t.Fatalf("creating module dir %s: %v", name, err)
+		}
+		goMod := "module example.com/nancytest/" + name + "\n\ngo 1.20\n"
+		if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte(goMod), 0644); err != nil {
+			t.Fatalf("writing go.mod for %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(moduleDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0644); err != nil {
+			t.Fatalf("writing main.go for %s: %v", name, err)
+		}
+	}
+
+	projectList, err := ResolveWorkspaceModuleGraph(filepath.Join(workDir, "go.work"))
+	if err != nil {
+		t.Fatalf("ResolveWorkspaceModuleGraph: unexpected error: %v", err)
+	}
+	if len(projectList) != 0 {
+		t.Errorf("expected no dependencies across either workspace module, got %v", projectList)
+	}
+}
+
+func TestResolveWorkspaceModuleGraphMissingFile(t *testing.T) {
+	if _, err := ResolveWorkspaceModuleGraph(filepath.Join(t.TempDir(), "go.work")); err == nil {
+		t.Fatal("expected an error for a missing go.work file")
+	}
+}
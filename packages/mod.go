@@ -0,0 +1,58 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package packages knows how to turn parsed manifests (go.sum, Gopkg.lock,
+// go list output) into package URLs (purls) suitable for querying OSS Index
+package packages
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Mod represents a Go module based manifest, either go.sum or the output of
+// `go list -m all`
+type Mod struct {
+	GoSumPath   string
+	ProjectList []string
+}
+
+// CheckExistenceOfManifest returns whether mod.GoSumPath exists on disk
+func (m Mod) CheckExistenceOfManifest() (bool, error) {
+	_, err := os.Stat(m.GoSumPath)
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// ExtractPurlsFromManifest turns each "module version" entry in
+// m.ProjectList into a pkg:golang purl
+func (m Mod) ExtractPurlsFromManifest() []string {
+	purls := make([]string, 0, len(m.ProjectList))
+	for _, entry := range m.ProjectList {
+		fields := strings.Fields(entry)
+		if len(fields) != 2 {
+			continue
+		}
+		purls = append(purls, fmt.Sprintf("pkg:golang/%s@%s", fields[0], fields[1]))
+	}
+	return purls
+}
@@ -0,0 +1,73 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package parse turns go list/go.sum output into a flat list of module@version strings
+package parse
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// GoList reads the output of `go list -m all` from scanner, returning one
+// "module version" entry per line (the main module itself is dropped, since
+// it never has a version)
+func GoList(scanner *bufio.Scanner) (projectList []string, err error) {
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !strings.Contains(line, " ") {
+			// the main module line has no version
+			continue
+		}
+		projectList = append(projectList, line)
+	}
+	err = scanner.Err()
+	return
+}
+
+// GoSum reads a go.sum file at path, returning one "module version" entry per
+// unique module, with the hash fields dropped
+func GoSum(path string) (projectList []string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		module, version := fields[0], strings.TrimSuffix(fields[1], "/go.mod")
+		key := module + " " + version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		projectList = append(projectList, key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return projectList, nil
+}
@@ -0,0 +1,129 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package reachability
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+const cacheDirName = "callgraph"
+
+// CacheDirectory returns the directory call graph results (reachable-symbol
+// sets) are cached in, alongside Nancy's OSS Index response cache under
+// $HOME/.ossindex
+func CacheDirectory() (string, error) {
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, types.OssIndexDirName, cacheDirName), nil
+}
+
+// RemoveCacheDirectory deletes the cached call graph results, so they get
+// recomputed on the next --call-graph run
+func RemoveCacheDirectory() error {
+	dir, err := CacheDirectory()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// cacheKey derives a stable key for the module rooted at dir from its
+// go.sum, so a changed dependency set invalidates the cache instead of
+// silently reusing a stale reachable-symbol set
+func cacheKey(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.sum"))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// loadCachedResult returns the Result cached for dir's current go.sum, if
+// any. Building the SSA program and call graph from scratch is by far the
+// most expensive part of --call-graph, so Analyze caches the computed
+// reachable-symbol set itself rather than the intermediate SSA artifacts.
+func loadCachedResult(dir string) (Result, bool) {
+	key, err := cacheKey(dir)
+	if err != nil {
+		return Result{}, false
+	}
+	cacheDir, err := CacheDirectory()
+	if err != nil {
+		return Result{}, false
+	}
+
+	data, err := os.ReadFile(filepath.Join(cacheDir, key+".json"))
+	if err != nil {
+		return Result{}, false
+	}
+	var result Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		return Result{}, false
+	}
+	return result, true
+}
+
+// storeCachedResult caches result for dir's current go.sum. Failing to
+// write the cache is never fatal to a scan, so callers only log the error.
+func storeCachedResult(dir string, result Result) error {
+	key, err := cacheKey(dir)
+	if err != nil {
+		return err
+	}
+	cacheDir, err := CacheDirectory()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(cacheDir, key+".json"), data, 0644)
+}
+
+// Demote walks coordinates and marks each vulnerability whose Symbols are
+// known but none of which are reachable as Informational, so it's still
+// visible to the user but no longer counts towards the exit code. A
+// vulnerability with no published Symbols is left as-is, since there's
+// nothing to prove it's unreachable.
+func Demote(coordinates []types.Coordinate, result Result) {
+	for i := range coordinates {
+		for j := range coordinates[i].Vulnerabilities {
+			v := &coordinates[i].Vulnerabilities[j]
+			if len(v.Symbols) == 0 {
+				continue
+			}
+			if !result.IsReachable(v.Symbols) {
+				v.Informational = true
+			}
+		}
+	}
+}
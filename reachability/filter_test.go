@@ -0,0 +1,53 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package reachability
+
+import (
+	"testing"
+
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+func TestDemote(t *testing.T) {
+	result := Result{ReachableSymbols: map[string]bool{
+		"example.com/foo.Reachable": true,
+	}}
+
+	coordinates := []types.Coordinate{
+		{
+			Coordinates: "pkg:golang/example.com/foo@1.0.0",
+			Vulnerabilities: []types.Vulnerability{
+				{ID: "reachable", Symbols: []string{"example.com/foo.Reachable"}},
+				{ID: "unreachable", Symbols: []string{"example.com/foo.Unreachable"}},
+				{ID: "no-symbols-published"},
+			},
+		},
+	}
+
+	Demote(coordinates, result)
+
+	vulns := coordinates[0].Vulnerabilities
+	if vulns[0].Informational {
+		t.Error("vulnerability with a reachable symbol should not be demoted")
+	}
+	if !vulns[1].Informational {
+		t.Error("vulnerability with no reachable symbols should be demoted to informational")
+	}
+	if vulns[2].Informational {
+		t.Error("vulnerability with no published symbols should be left alone")
+	}
+}
@@ -0,0 +1,176 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package reachability builds a call graph for a Go module and uses it to
+// tell which vulnerable symbols are actually reachable from the module's
+// main packages, the same approach golang.org/x/vuln/cmd/govulncheck uses to
+// cut down false positives in a dependency-level vulnerability scan.
+package reachability
+
+import (
+	"fmt"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+// loadMode is the set of packages.NeedX bits required to build SSA and a
+// precise (VTA) call graph
+const loadMode = packages.NeedName |
+	packages.NeedTypes |
+	packages.NeedDeps |
+	packages.NeedSyntax |
+	packages.NeedImports |
+	packages.NeedTypesInfo |
+	packages.NeedModule
+
+// Result is the set of fully qualified symbols (package path + "." +
+// function/method name) reachable from dir's main packages
+type Result struct {
+	ReachableSymbols map[string]bool
+}
+
+// IsReachable reports whether any of symbols was found reachable
+func (r Result) IsReachable(symbols []string) bool {
+	for _, s := range symbols {
+		if r.ReachableSymbols[s] {
+			return true
+		}
+	}
+	return false
+}
+
+// Analyze loads the Go module rooted at dir, builds an SSA program for it,
+// and computes the set of symbols reachable from its main packages. It tries
+// the more precise VTA call graph algorithm first, falling back to the
+// cheaper (and less precise) CHA algorithm if VTA panics while building. A
+// module whose packages fail to load or type-check is reported as an error
+// instead.
+func Analyze(dir string) (Result, error) {
+	if result, ok := loadCachedResult(dir); ok {
+		return result, nil
+	}
+
+	cfg := &packages.Config{
+		Mode: loadMode,
+		Dir:  dir,
+	}
+
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		return Result{}, fmt.Errorf("loading packages in %s: %w", dir, err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return Result{}, fmt.Errorf("one or more packages in %s had errors", dir)
+	}
+
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	var mains []*ssa.Package
+	for _, p := range ssaPkgs {
+		if p != nil && p.Pkg.Name() == "main" {
+			mains = append(mains, p)
+		}
+	}
+	if len(mains) == 0 {
+		return Result{}, fmt.Errorf("no main packages found under %s", dir)
+	}
+
+	roots := make([]*ssa.Function, 0, len(mains))
+	for _, m := range mains {
+		if fn := m.Func("main"); fn != nil {
+			roots = append(roots, fn)
+		}
+	}
+
+	cg, err := buildCallGraph(prog, ssaPkgs, roots)
+	if err != nil {
+		return Result{}, err
+	}
+
+	reachable := map[string]bool{}
+	visited := map[*callgraph.Node]bool{}
+	var walk func(n *callgraph.Node)
+	walk = func(n *callgraph.Node) {
+		if n == nil || visited[n] {
+			return
+		}
+		visited[n] = true
+		if n.Func != nil {
+			reachable[symbolName(n.Func)] = true
+		}
+		for _, e := range n.Out {
+			walk(e.Callee)
+		}
+	}
+	for _, root := range roots {
+		walk(cg.Nodes[root])
+		reachable[symbolName(root)] = true
+	}
+
+	result := Result{ReachableSymbols: reachable}
+	if err := storeCachedResult(dir, result); err != nil {
+		fmt.Printf("WARN: could not cache call graph result for %s: %v\n", dir, err)
+	}
+	return result, nil
+}
+
+// buildCallGraph tries VTA first, and falls back to CHA, which is less
+// precise but cannot itself fail to build.
+func buildCallGraph(prog *ssa.Program, ssaPkgs []*ssa.Package, roots []*ssa.Function) (cg *callgraph.Graph, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			cg = cha.CallGraph(prog)
+			err = nil
+		}
+	}()
+
+	var allFuncs []*ssa.Function
+	for _, p := range ssaPkgs {
+		if p == nil {
+			continue
+		}
+		for _, m := range p.Members {
+			if fn, ok := m.(*ssa.Function); ok {
+				allFuncs = append(allFuncs, fn)
+			}
+		}
+	}
+
+	return vta.CallGraph(vtaFuncSet(allFuncs), cha.CallGraph(prog)), nil
+}
+
+func vtaFuncSet(fns []*ssa.Function) map[*ssa.Function]bool {
+	set := make(map[*ssa.Function]bool, len(fns))
+	for _, fn := range fns {
+		set[fn] = true
+	}
+	return set
+}
+
+// symbolName returns fn's fully package-qualified symbol name, e.g.
+// "github.com/foo/bar.DoThing" or "github.com/foo/bar.(*Type).Method"
+func symbolName(fn *ssa.Function) string {
+	if fn.Pkg == nil {
+		return fn.String()
+	}
+	return fn.Pkg.Pkg.Path() + "." + fn.RelString(fn.Pkg.Pkg)
+}
@@ -0,0 +1,78 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package types has definitions and functions for dealing with OSS Index
+// coordinates and vulnerabilities
+package types
+
+const (
+	// OssIndexDirName is the directory, relative to the user's home directory,
+	// where Nancy stores its config and cache
+	OssIndexDirName = ".ossindex"
+	// OssIndexConfigFileName is the name of the Nancy/OSS Index config file
+	OssIndexConfigFileName = ".oss-index-config"
+)
+
+// Coordinate is a package coordinate, as returned by OSS Index, along with any
+// vulnerabilities found for it
+type Coordinate struct {
+	Coordinates     string          `json:"coordinates"`
+	Reference       string          `json:"reference"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+	InvalidSemVer   bool            `json:"-"`
+}
+
+// Vulnerability describes a single vulnerability affecting a Coordinate
+type Vulnerability struct {
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	CvssScore   float64 `json:"cvssScore"`
+	CvssVector  string  `json:"cvssVector"`
+	Cve         string  `json:"cve"`
+	Reference   string  `json:"reference"`
+	Excluded    bool    `json:"-"`
+	// Symbols is the list of fully package-qualified functions/methods this
+	// vulnerability affects, as reported by the data source. Empty when the
+	// data source doesn't publish symbol-level detail.
+	Symbols []string `json:"symbols,omitempty"`
+	// Informational is set when --call-graph determined none of Symbols are
+	// reachable from the scanned module's main packages. Informational
+	// vulnerabilities are reported separately and don't affect the exit code.
+	Informational bool `json:"informational,omitempty"`
+	// Suppressed is set when the vulnerability doesn't meet the --fail-on/
+	// --min-cvss/--fail-on-unfixed policy. Unlike Excluded, a suppressed
+	// finding wasn't explicitly ignored by the user, just below their policy
+	// threshold, so it's still printed, only dimmed/flagged rather than hidden.
+	Suppressed bool `json:"suppressed,omitempty"`
+	// Unfixed is true when the data source has no fixed version to offer yet
+	Unfixed bool `json:"unfixed,omitempty"`
+	// IgnoreExpired is set when a matching .nancy-ignore entry exists but its
+	// until= date has passed, so the finding is re-surfaced instead of
+	// silently staying excluded
+	IgnoreExpired bool `json:"ignore_expired,omitempty"`
+}
+
+// IsVulnerableTo returns true if the Coordinate has at least one vulnerability
+// that is neither excluded, demoted to informational, nor suppressed by policy
+func (c Coordinate) IsVulnerableTo() bool {
+	for _, v := range c.Vulnerabilities {
+		if !v.Excluded && !v.Informational && !v.Suppressed {
+			return true
+		}
+	}
+	return false
+}
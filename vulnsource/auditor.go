@@ -0,0 +1,27 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package vulnsource abstracts over Nancy's vulnerability data sources
+// (Sonatype OSS Index, OSV.dev, the Go vulnerability database), so the rest
+// of Nancy can audit a purl list without caring which one answered it.
+package vulnsource
+
+import "github.com/sonatype-nexus-community/nancy/types"
+
+// Auditor queries a vulnerability data source for the given purls
+type Auditor interface {
+	Audit(purls []string) ([]types.Coordinate, error)
+}
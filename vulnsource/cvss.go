@@ -0,0 +1,88 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package vulnsource
+
+import (
+	"math"
+	"strings"
+)
+
+// These are the CVSS v3.0/v3.1 base metric numeric weights, as
+// defined by the First.org specification
+var (
+	cvssV3AttackVector       = map[string]float64{"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2}
+	cvssV3AttackComplexity   = map[string]float64{"L": 0.77, "H": 0.44}
+	cvssV3UserInteraction    = map[string]float64{"N": 0.85, "R": 0.62}
+	cvssV3Impact             = map[string]float64{"N": 0, "L": 0.22, "H": 0.56}
+	cvssV3PrivilegesRequired = map[string]float64{"N": 0.85, "L": 0.62, "H": 0.27}
+	cvssV3PrivilegesScoped   = map[string]float64{"N": 0.85, "L": 0.68, "H": 0.5}
+)
+
+// parseCvssV3BaseScore computes the CVSS v3.0/v3.1 base score from a vector
+// string like "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", since OSV.dev's
+// severity[].score field carries only the vector, not a precomputed number
+func parseCvssV3BaseScore(vector string) (float64, bool) {
+	metrics := map[string]string{}
+	for _, part := range strings.Split(vector, "/") {
+		key, value, ok := strings.Cut(part, ":")
+		if ok {
+			metrics[key] = value
+		}
+	}
+
+	scopeChanged := metrics["S"] == "C"
+	prTable := cvssV3PrivilegesRequired
+	if scopeChanged {
+		prTable = cvssV3PrivilegesScoped
+	}
+
+	av, avOK := cvssV3AttackVector[metrics["AV"]]
+	ac, acOK := cvssV3AttackComplexity[metrics["AC"]]
+	pr, prOK := prTable[metrics["PR"]]
+	ui, uiOK := cvssV3UserInteraction[metrics["UI"]]
+	c, cOK := cvssV3Impact[metrics["C"]]
+	i, iOK := cvssV3Impact[metrics["I"]]
+	a, aOK := cvssV3Impact[metrics["A"]]
+	if !(avOK && acOK && prOK && uiOK && cOK && iOK && aOK) {
+		return 0, false
+	}
+
+	impactSubscore := 1 - (1-c)*(1-i)*(1-a)
+	var impact float64
+	if scopeChanged {
+		impact = 7.52*(impactSubscore-0.029) - 3.25*math.Pow(impactSubscore-0.02, 15)
+	} else {
+		impact = 6.42 * impactSubscore
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+
+	exploitability := 8.22 * av * ac * pr * ui
+
+	base := impact + exploitability
+	if scopeChanged {
+		base = 1.08 * base
+	}
+	return roundUpToOneDecimal(math.Min(base, 10)), true
+}
+
+// roundUpToOneDecimal implements the CVSS spec's "round up" function, which
+// is not the same as ordinary rounding (e.g. 4.02 rounds up to 4.1)
+func roundUpToOneDecimal(value float64) float64 {
+	return math.Ceil(value*10) / 10
+}
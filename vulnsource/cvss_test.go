@@ -0,0 +1,64 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package vulnsource
+
+import "testing"
+
+func TestParseCvssV3BaseScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		vector string
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "critical, unchanged scope",
+			vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+			want:   9.8,
+			wantOK: true,
+		},
+		{
+			name:   "medium, unchanged scope",
+			vector: "CVSS:3.1/AV:N/AC:L/PR:L/UI:R/S:U/C:L/I:L/A:N",
+			want:   4.6,
+			wantOK: true,
+		},
+		{
+			name:   "no impact is scored zero",
+			vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N",
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:   "malformed vector",
+			vector: "not a vector",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseCvssV3BaseScore(tt.vector)
+			if ok != tt.wantOK {
+				t.Fatalf("parseCvssV3BaseScore(%q) ok = %v, want %v", tt.vector, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("parseCvssV3BaseScore(%q) = %v, want %v", tt.vector, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package vulnsource
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+const govulndbDefaultBase = "https://vuln.go.dev"
+const filePrefix = "file://"
+
+// GovulndbAuditor audits against the Go vulnerability database JSON index
+// served at vuln.go.dev (or a local mirror via --db=file:///path), giving
+// users an authentication-free, offline-capable alternative to OSS Index
+type GovulndbAuditor struct {
+	// Base is either an https:// URL (default https://vuln.go.dev) or a
+	// file:// path to a local mirror of the same index layout
+	Base       string
+	HTTPClient *http.Client
+}
+
+// NewGovulndbAuditor returns a GovulndbAuditor against the public vuln.go.dev
+// index, or base if non-empty
+func NewGovulndbAuditor(base string) *GovulndbAuditor {
+	if base == "" {
+		base = govulndbDefaultBase
+	}
+	return &GovulndbAuditor{Base: base, HTTPClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+type govulndbModuleEntry struct {
+	Module string   `json:"module"`
+	Vulns  []string `json:"vulns"`
+}
+
+type govulndbEvent struct {
+	Introduced string `json:"introduced"`
+	Fixed      string `json:"fixed"`
+}
+
+type govulndbRange struct {
+	Events []govulndbEvent `json:"events"`
+}
+
+type govulndbAffected struct {
+	Module struct {
+		Path string `json:"path"`
+	} `json:"module"`
+	Ranges []govulndbRange `json:"ranges"`
+}
+
+type govulndbEntry struct {
+	ID         string             `json:"id"`
+	Summary    string             `json:"summary"`
+	Details    string             `json:"details"`
+	Aliases    []string           `json:"aliases"`
+	Affected   []govulndbAffected `json:"affected"`
+	References []struct {
+		URL string `json:"url"`
+	} `json:"references"`
+}
+
+// Audit implements vulnsource.Auditor by walking /index/modules.json to find
+// which Go vulnerability database IDs affect each module, then fetching the
+// full /ID/GO-YYYY-NNNN.json record for each one found
+func (a *GovulndbAuditor) Audit(purls []string) ([]types.Coordinate, error) {
+	index, err := a.loadModuleIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	coordinates := make([]types.Coordinate, 0, len(purls))
+	for _, purl := range purls {
+		name, version, ok := parseGolangPurl(purl)
+		if !ok {
+			coordinates = append(coordinates, types.Coordinate{Coordinates: purl})
+			continue
+		}
+
+		coordinate := types.Coordinate{Coordinates: purl}
+		for _, id := range index[name] {
+			entry, err := a.loadEntry(id)
+			if err != nil {
+				return nil, err
+			}
+			if !affects(entry, name, version) {
+				continue
+			}
+			coordinate.Vulnerabilities = append(coordinate.Vulnerabilities, toVulnerability(entry, name))
+		}
+		coordinates = append(coordinates, coordinate)
+	}
+	return coordinates, nil
+}
+
+// loadModuleIndex reads /index/modules.json, mapping module path to the IDs
+// of vulnerabilities that affect it
+func (a *GovulndbAuditor) loadModuleIndex() (map[string][]string, error) {
+	data, err := a.fetch("/index/modules.json")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []govulndbModuleEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parsing govulndb module index: %w", err)
+	}
+
+	index := make(map[string][]string, len(entries))
+	for _, e := range entries {
+		index[e.Module] = e.Vulns
+	}
+	return index, nil
+}
+
+// loadEntry fetches /ID/<id>.json
+func (a *GovulndbAuditor) loadEntry(id string) (govulndbEntry, error) {
+	data, err := a.fetch("/ID/" + id + ".json")
+	if err != nil {
+		return govulndbEntry{}, err
+	}
+	var entry govulndbEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return govulndbEntry{}, fmt.Errorf("parsing govulndb entry %s: %w", id, err)
+	}
+	return entry, nil
+}
+
+// fetch reads path relative to a.Base, which may be an http(s):// URL or a
+// file:// mirror root
+func (a *GovulndbAuditor) fetch(path string) ([]byte, error) {
+	if strings.HasPrefix(a.Base, filePrefix) {
+		return os.ReadFile(strings.TrimPrefix(a.Base, filePrefix) + path)
+	}
+
+	client := a.HTTPClient
+	if client == nil {
+		client = NewGovulndbAuditor(a.Base).HTTPClient
+	}
+	resp, err := client.Get(a.Base + path)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s%s: %w", a.Base, path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s%s returned status %d", a.Base, path, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// affects reports whether entry has an affected range for module that
+// contains version. A module entry with no ranges at all (the database
+// couldn't narrow it down) or a version Nancy can't parse as semver is
+// treated conservatively as affected.
+func affects(entry govulndbEntry, module string, version string) bool {
+	target := canonicalSemver(version)
+	for _, aff := range entry.Affected {
+		if aff.Module.Path != module {
+			continue
+		}
+		if target == "" || rangesAffect(aff.Ranges, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// rangesAffect reports whether target falls inside any [introduced, fixed)
+// window described by ranges
+func rangesAffect(ranges []govulndbRange, target string) bool {
+	if len(ranges) == 0 {
+		return true
+	}
+	for _, r := range ranges {
+		if windowsAffect(r.Events, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// windowsAffect walks events in the order vuln.go.dev publishes them,
+// building a new [introduced, fixed) window each time it sees an introduced
+// event, since a single range can have more than one -- e.g. a vulnerability
+// fixed and later reintroduced -- and a version vulnerable under an earlier
+// window must not be masked by a later one
+func windowsAffect(events []govulndbEvent, target string) bool {
+	var introduced string
+	haveWindow := false
+
+	for _, e := range events {
+		if e.Introduced != "" {
+			introduced = canonicalSemver(e.Introduced)
+			haveWindow = true
+		}
+		if e.Fixed != "" && haveWindow {
+			if windowContains(introduced, canonicalSemver(e.Fixed), target) {
+				return true
+			}
+			haveWindow = false
+		}
+	}
+	// An introduced event with no matching fixed event means the window is
+	// still open, i.e. every version from introduced onwards is affected
+	return haveWindow && windowContains(introduced, "", target)
+}
+
+// windowContains reports whether target falls in [introduced, fixed). An
+// empty or "0" introduced affects from the beginning of history; an empty
+// fixed means the window is still open.
+func windowContains(introduced string, fixed string, target string) bool {
+	if introduced != "" && introduced != "v0" && semver.Compare(target, introduced) < 0 {
+		return false
+	}
+	if fixed != "" && semver.Compare(target, fixed) >= 0 {
+		return false
+	}
+	return true
+}
+
+// canonicalSemver adds the "v" prefix golang.org/x/mod/semver requires, and
+// returns "" if the result still isn't valid semver (e.g. a branch name),
+// so callers can fail safe instead of miscomparing
+func canonicalSemver(version string) string {
+	if version == "" {
+		return ""
+	}
+	v := version
+	if !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+	if !semver.IsValid(v) {
+		return ""
+	}
+	return v
+}
+
+func toVulnerability(entry govulndbEntry, module string) types.Vulnerability {
+	v := types.Vulnerability{
+		ID:          entry.ID,
+		Title:       entry.Summary,
+		Description: entry.Details,
+		Cve:         cveAliasFrom(entry.Aliases),
+		Unfixed:     unfixedFor(entry, module),
+	}
+	if len(entry.References) > 0 {
+		v.Reference = entry.References[0].URL
+	}
+	return v
+}
+
+// rangeIsOpen reports whether r's last window has no fixed upper bound, i.e.
+// the module is still vulnerable in its newest known versions
+func rangeIsOpen(r govulndbRange) bool {
+	haveWindow := false
+	for _, e := range r.Events {
+		if e.Introduced != "" {
+			haveWindow = true
+		}
+		if e.Fixed != "" {
+			haveWindow = false
+		}
+	}
+	return haveWindow
+}
+
+// unfixedFor reports whether entry has no known fix yet for module, i.e. at
+// least one of its ranges is still open. This is what --fail-on-unfixed
+// keys off via types.Vulnerability.Unfixed.
+func unfixedFor(entry govulndbEntry, module string) bool {
+	for _, aff := range entry.Affected {
+		if aff.Module.Path != module {
+			continue
+		}
+		for _, r := range aff.Ranges {
+			if rangeIsOpen(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package vulnsource
+
+import "testing"
+
+func TestRangesAffectFixedThenReintroduced(t *testing.T) {
+	// vulnerable in [0, 1.0.0), fixed in 1.0.0, reintroduced in 1.2.0,
+	// still unfixed as of the newest known version
+	ranges := []govulndbRange{
+		{
+			Events: []govulndbEvent{
+				{Introduced: "0"},
+				{Fixed: "1.0.0"},
+				{Introduced: "1.2.0"},
+			},
+		},
+	}
+
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"0.9.0", true},
+		{"1.0.0", false},
+		{"1.1.0", false},
+		{"1.2.0", true},
+		{"1.5.0", true},
+	}
+
+	for _, tt := range tests {
+		target := canonicalSemver(tt.version)
+		if got := rangesAffect(ranges, target); got != tt.want {
+			t.Errorf("rangesAffect(%s) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestRangesAffectNoRangesIsConservative(t *testing.T) {
+	if !rangesAffect(nil, canonicalSemver("1.0.0")) {
+		t.Error("expected no ranges to conservatively report affected")
+	}
+}
+
+func TestRangesAffectClosedWindow(t *testing.T) {
+	ranges := []govulndbRange{
+		{
+			Events: []govulndbEvent{
+				{Introduced: "1.0.0"},
+				{Fixed: "2.0.0"},
+			},
+		},
+	}
+
+	if rangesAffect(ranges, canonicalSemver("0.9.0")) {
+		t.Error("version before introduced should not be affected")
+	}
+	if !rangesAffect(ranges, canonicalSemver("1.5.0")) {
+		t.Error("version inside window should be affected")
+	}
+	if rangesAffect(ranges, canonicalSemver("2.0.0")) {
+		t.Error("version at fixed boundary should not be affected")
+	}
+}
+
+func TestUnfixedFor(t *testing.T) {
+	entry := govulndbEntry{
+		Affected: []govulndbAffected{
+			{
+				Module: struct {
+					Path string `json:"path"`
+				}{Path: "example.com/foo"},
+				Ranges: []govulndbRange{
+					{
+						Events: []govulndbEvent{
+							{Introduced: "0"},
+							{Fixed: "1.0.0"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if unfixedFor(entry, "example.com/foo") {
+		t.Error("range with a terminal fixed event should be considered fixed")
+	}
+
+	entry.Affected[0].Ranges[0].Events = append(entry.Affected[0].Ranges[0].Events, govulndbEvent{Introduced: "1.2.0"})
+	if !unfixedFor(entry, "example.com/foo") {
+		t.Error("a reintroduced, still-open window should be considered unfixed")
+	}
+
+	if unfixedFor(entry, "example.com/other") {
+		t.Error("a module not listed as affected should not be reported unfixed")
+	}
+}
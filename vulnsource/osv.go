@@ -0,0 +1,207 @@
+//
+// Copyright 2018-present Sonatype Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package vulnsource
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sonatype-nexus-community/nancy/types"
+)
+
+const osvBatchURL = "https://api.osv.dev/v1/querybatch"
+const osvVulnURL = "https://api.osv.dev/v1/vulns/"
+
+// OSVAuditor queries OSV.dev (https://osv.dev), an open, authentication-free
+// vulnerability database that covers the Go ecosystem among many others
+type OSVAuditor struct {
+	HTTPClient *http.Client
+}
+
+// NewOSVAuditor returns an OSVAuditor with a sane default HTTP timeout
+func NewOSVAuditor() *OSVAuditor {
+	return &OSVAuditor{HTTPClient: &http.Client{Timeout: 30 * time.Second}}
+}
+
+type osvPackage struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+type osvBatchQuery struct {
+	Package osvPackage `json:"package"`
+	Version string     `json:"version"`
+}
+
+type osvBatchRequest struct {
+	Queries []osvBatchQuery `json:"queries"`
+}
+
+type osvVulnRef struct {
+	ID string `json:"id"`
+}
+
+type osvBatchResponse struct {
+	Results []struct {
+		Vulns []osvVulnRef `json:"vulns"`
+	} `json:"results"`
+}
+
+type osvSeverity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+type osvVuln struct {
+	ID         string        `json:"id"`
+	Summary    string        `json:"summary"`
+	Details    string        `json:"details"`
+	Aliases    []string      `json:"aliases"`
+	Severity   []osvSeverity `json:"severity"`
+	References []struct {
+		URL string `json:"url"`
+	} `json:"references"`
+}
+
+// Audit implements vulnsource.Auditor by POSTing purls to OSV.dev's batch
+// query endpoint, then hydrating each returned vulnerability id
+// individually, since the batch endpoint intentionally omits details.
+func (a *OSVAuditor) Audit(purls []string) ([]types.Coordinate, error) {
+	client := a.HTTPClient
+	if client == nil {
+		client = NewOSVAuditor().HTTPClient
+	}
+
+	// queryPurlIndex[i] is the index into purls/coordinates that queries[i]
+	// was built from, since purls that aren't Go purls are skipped rather
+	// than queried, which would otherwise shift every later result
+	queries := make([]osvBatchQuery, 0, len(purls))
+	queryPurlIndex := make([]int, 0, len(purls))
+	for i, purl := range purls {
+		name, version, ok := parseGolangPurl(purl)
+		if !ok {
+			continue
+		}
+		queries = append(queries, osvBatchQuery{Package: osvPackage{Ecosystem: "Go", Name: name}, Version: version})
+		queryPurlIndex = append(queryPurlIndex, i)
+	}
+
+	coordinates := make([]types.Coordinate, len(purls))
+	for i, purl := range purls {
+		coordinates[i] = types.Coordinate{Coordinates: purl}
+	}
+	if len(queries) == 0 {
+		return coordinates, nil
+	}
+
+	body, err := json.Marshal(osvBatchRequest{Queries: queries})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Post(osvBatchURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("querying OSV.dev: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OSV.dev returned status %d", resp.StatusCode)
+	}
+
+	var batchResp osvBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batchResp); err != nil {
+		return nil, fmt.Errorf("decoding OSV.dev batch response: %w", err)
+	}
+
+	for i, result := range batchResp.Results {
+		if i >= len(queryPurlIndex) {
+			break
+		}
+		coordinate := &coordinates[queryPurlIndex[i]]
+		for _, ref := range result.Vulns {
+			vuln, err := a.hydrate(client, ref.ID)
+			if err != nil {
+				return nil, err
+			}
+			coordinate.Vulnerabilities = append(coordinate.Vulnerabilities, vuln)
+		}
+	}
+	return coordinates, nil
+}
+
+// hydrate fetches full detail for a single OSV vulnerability id
+func (a *OSVAuditor) hydrate(client *http.Client, id string) (types.Vulnerability, error) {
+	resp, err := client.Get(osvVulnURL + id)
+	if err != nil {
+		return types.Vulnerability{}, fmt.Errorf("fetching OSV.dev vuln %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return types.Vulnerability{}, fmt.Errorf("OSV.dev returned status %d for vuln %s", resp.StatusCode, id)
+	}
+
+	var vuln osvVuln
+	if err := json.NewDecoder(resp.Body).Decode(&vuln); err != nil {
+		return types.Vulnerability{}, fmt.Errorf("decoding OSV.dev vuln %s: %w", id, err)
+	}
+
+	v := types.Vulnerability{
+		ID:          vuln.ID,
+		Title:       vuln.Summary,
+		Description: vuln.Details,
+		Cve:         cveAliasFrom(vuln.Aliases),
+	}
+	if len(vuln.References) > 0 {
+		v.Reference = vuln.References[0].URL
+	}
+	for _, s := range vuln.Severity {
+		if s.Type == "CVSS_V3" {
+			v.CvssVector = s.Score
+			if score, ok := parseCvssV3BaseScore(s.Score); ok {
+				v.CvssScore = score
+			}
+		}
+	}
+	return v, nil
+}
+
+// cveAliasFrom returns the first CVE-prefixed alias, since OSV ids
+// themselves (e.g. GO-2023-1234, GHSA-...) aren't CVEs
+func cveAliasFrom(aliases []string) string {
+	for _, alias := range aliases {
+		if strings.HasPrefix(alias, "CVE-") {
+			return alias
+		}
+	}
+	return ""
+}
+
+// parseGolangPurl extracts the module name and version from a
+// pkg:golang/<module>@<version> purl
+func parseGolangPurl(purl string) (name string, version string, ok bool) {
+	const prefix = "pkg:golang/"
+	if !strings.HasPrefix(purl, prefix) {
+		return "", "", false
+	}
+	rest := strings.TrimPrefix(purl, prefix)
+	name, version, ok = strings.Cut(rest, "@")
+	return name, version, ok
+}